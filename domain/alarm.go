@@ -0,0 +1,55 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 23. 오전 11:20
+ */
+
+package domain
+
+const (
+	AlarmStateFiring       = "firing"
+	AlarmStateAcknowledged = "acknowledged"
+	AlarmStateResolved     = "resolved"
+	AlarmStateSilenced     = "silenced"
+)
+
+// Alarm is lifecycle of ALARM message. same alarm received again while open increases Count
+// time values are unix millis
+type Alarm struct {
+	Id          string          `json:"id"`
+	Fingerprint string          `json:"fingerprint"`
+	State       string          `json:"state"`
+	Message     MBusMessageBody `json:"message"` // first message of the alarm
+	Count       int             `json:"count"`
+	FiredAt     int             `json:"fired_at"`
+	LastAt      int             `json:"last_at"`
+	UpdatedAt   int             `json:"updated_at"`
+	UpdatedBy   string          `json:"updated_by,omitempty"` // who changed the state (or silence id)
+	Comment     string          `json:"comment,omitempty"`
+	Notified    []string        `json:"notified,omitempty"` // notifiers which received the alarm
+}
+
+// IsOpen returns true when the alarm is not closed (resolved or silenced)
+func (a Alarm) IsOpen() bool {
+	return a.State == AlarmStateFiring || a.State == AlarmStateAcknowledged
+}
+
+// AlarmStateNotify is implemented by notifier which can update alarm it delivered when the state changes
+// (e.g. slack thread reply)
+type AlarmStateNotify interface {
+	SendAlarmState(alarm Alarm)
+}
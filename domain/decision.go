@@ -0,0 +1,45 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 27. 오후 2:20
+ */
+
+package domain
+
+// what saturn did with the message
+const (
+	DecisionNotified  = "notified"  // sent to notifiers (routed)
+	DecisionFiltered  = "filtered"  // excluded by filter rule (e.g. opm process)
+	DecisionSilenced  = "silenced"  // matched active silence
+	DecisionFlapping  = "flapping"  // suppressed while the process is flapping
+	DecisionRedundant = "redundant" // suppressed as duplicate
+	DecisionStorm     = "storm"     // suppressed by storm protection
+	DecisionRestart   = "restart"   // held to be merged with startup (restarted message)
+	DecisionHeld      = "held"      // held by digest rule and delivered later as digest
+	DecisionRejected  = "rejected"  // not accepted because notifier queue is full. sender may retry
+)
+
+// Decision is an accepted message with what saturn did with it
+// time values are unix millis
+type Decision struct {
+	Id        string          `json:"id,omitempty"`
+	Message   MBusMessageBody `json:"message"`
+	Result    string          `json:"result"`
+	SilenceId string          `json:"silence_id,omitempty"`
+	Notified  []string        `json:"notified,omitempty"` // notifiers the message was routed to
+	DecidedAt int             `json:"decided_at"`
+}
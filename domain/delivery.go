@@ -0,0 +1,76 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 25. 오후 3:10
+ */
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrQueueFull is reported (wrapped) by notifier which drops message because its queue is full
+var ErrQueueFull = errors.New("queue is full")
+
+// AttemptError is reported (wrapped) by notifier which gave up the message after its own retry
+type AttemptError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *AttemptError) Error() string {
+	return fmt.Sprintf("give up after %d attempts : %s", e.Attempts, e.Err.Error())
+}
+
+func (e *AttemptError) Unwrap() error {
+	return e.Err
+}
+
+// GetAttempts returns attempts of failed delivery. 1 if the notifier did not retry
+func GetAttempts(err error) int {
+	var attemptErr *AttemptError
+	if errors.As(err, &attemptErr) {
+		return attemptErr.Attempts
+	}
+	return 1
+}
+
+// ReliableNotify is implemented by notifier which reports delivery result.
+// done is called once after the message is delivered (nil) or failed (error)
+type ReliableNotify interface {
+	SendNotifyWithAck(mbus MBusMessageBody, done func(err error))
+}
+
+// QueueNotify is implemented by notifier which sends message through its own queue.
+// saturn checks it before the message changes any state so that rejected message can be sent again
+type QueueNotify interface {
+	IsQueueFull() bool
+}
+
+// Delivery is a message to a notifier kept in outbox until the notifier acknowledges it
+// time values are unix millis
+type Delivery struct {
+	Id            string          `json:"id"`
+	Notifier      string          `json:"notifier"`
+	Message       MBusMessageBody `json:"message"`
+	CreatedAt     int             `json:"created_at"`
+	Attempts      int             `json:"attempts"`
+	LastError     string          `json:"last_error,omitempty"`
+	LastAttemptAt int             `json:"last_attempt_at,omitempty"`
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 19. 오후 6:20
+ */
+
+package domain
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	regexPatternPrefix = "~"
+)
+
+// MessageMatcher matches message fields with glob pattern (e.g. batch-*)
+// pattern which starts with '~' is treated as regular expression (e.g. ~^db[0-9]+$)
+// empty field matches everything
+type MessageMatcher struct {
+	Profile    string `json:"profile,omitempty"`
+	Group      string `json:"group,omitempty"`
+	Host       string `json:"host,omitempty"`
+	Process    string `json:"process,omitempty"`
+	AlarmLevel string `json:"alarm_level,omitempty"`
+	Type       string `json:"type,omitempty"`
+	Action     string `json:"action,omitempty"`
+	Category   string `json:"category,omitempty"`
+	Message    string `json:"message,omitempty"`
+
+	patterns []fieldPattern
+	compiled bool
+}
+
+type fieldPattern struct {
+	field string
+	match func(s string) bool
+}
+
+// Compile prepares patterns. Match compiles lazily but invalid pattern is reported only here
+func (m *MessageMatcher) Compile() error {
+	m.patterns = make([]fieldPattern, 0)
+	fields := []struct {
+		name    string
+		pattern string
+	}{
+		{FieldProfile, m.Profile},
+		{FieldGroup, m.Group},
+		{FieldHost, m.Host},
+		{FieldProcess, m.Process},
+		{FieldAlarmLevel, m.AlarmLevel},
+		{FieldType, m.Type},
+		{FieldAction, m.Action},
+		{FieldCategory, m.Category},
+		{FieldMessage, m.Message},
+	}
+
+	for _, f := range fields {
+		if len(f.pattern) == 0 {
+			continue
+		}
+		match, err := compilePattern(f.pattern)
+		if err != nil {
+			return fmt.Errorf("invalid %s pattern [%s] : %s", f.name, f.pattern, err.Error())
+		}
+		m.patterns = append(m.patterns, fieldPattern{field: f.name, match: match})
+	}
+
+	m.compiled = true
+	return nil
+}
+
+func (m *MessageMatcher) Match(mbus MBusMessageBody) bool {
+	if !m.compiled {
+		if err := m.Compile(); err != nil {
+			return false
+		}
+	}
+
+	for _, p := range m.patterns {
+		value, _ := mbus.GetFieldValue(p.field)
+		if !p.match(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *MessageMatcher) IsEmpty() bool {
+	return len(m.Profile) == 0 &&
+		len(m.Group) == 0 &&
+		len(m.Host) == 0 &&
+		len(m.Process) == 0 &&
+		len(m.AlarmLevel) == 0 &&
+		len(m.Type) == 0 &&
+		len(m.Action) == 0 &&
+		len(m.Category) == 0 &&
+		len(m.Message) == 0
+}
+
+func compilePattern(pattern string) (func(s string) bool, error) {
+	if strings.HasPrefix(pattern, regexPatternPrefix) {
+		re, err := regexp.Compile(pattern[len(regexPatternPrefix):])
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString, nil
+	}
+
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	return func(s string) bool {
+		ok, _ := path.Match(pattern, s)
+		return ok
+	}, nil
+}
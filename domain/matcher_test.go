@@ -0,0 +1,60 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 19. 오후 6:20
+ */
+
+package domain
+
+import (
+	"testing"
+)
+
+func TestMessageMatcher(t *testing.T) {
+	m := MBusMessageBody{}
+	m.Message = make(map[string]interface{})
+	m.PackageGroup = "db_group"
+	m.PackageHost = "db01"
+	m.PackageProcess = "batch-daily"
+	m.Message[MessageKeyType] = NotifyAlarm
+	m.Message[MessageKeyAlarmLevel] = AlarmLevelMajor
+
+	matcher := MessageMatcher{Process: "batch-*", AlarmLevel: AlarmLevelMajor}
+	if !matcher.Match(m) {
+		t.Fatalf("glob pattern should match")
+	}
+
+	matcher = MessageMatcher{Host: "~^db[0-9]+$", Category: "monitor"}
+	if matcher.Match(m) {
+		t.Fatalf("category should not match")
+	}
+
+	matcher = MessageMatcher{Host: "~^db[0-9]+$"}
+	if !matcher.Match(m) {
+		t.Fatalf("regex pattern should match")
+	}
+
+	matcher = MessageMatcher{Host: "~^db[0-9+$"}
+	if matcher.Compile() == nil {
+		t.Fatalf("invalid regex should be reported")
+	}
+
+	matcher = MessageMatcher{}
+	if !matcher.Match(m) {
+		t.Fatalf("empty matcher should match everything")
+	}
+}
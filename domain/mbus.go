@@ -26,6 +26,7 @@ import (
 	"encoding/json"
 	"fmt"
 	log "github.com/fatima-go/fatima-log"
+	"regexp"
 )
 
 const (
@@ -35,13 +36,56 @@ const (
 	MessageKeyCategory   = "category"
 	MessageKeyMessage    = "message"
 	MessageKeyDeployment = "deployment"
+	MessageKeyTimestamp  = "timestamp"
+	MessageKeyFrom       = "from"
+	MessageKeyInitiator  = "initiator"
+	MessageKeyParams     = "params"
 	AlarmLevelWarn       = "WARN"
 	AlarmLevelMinor      = "MINOR"
 	AlarmLevelMajor      = "MAJOR"
 )
 
+// keys added by saturn
+const (
+	MessageKeyRepeatCount = "repeat_count" // summary of suppressed redundant messages
+	MessageKeyStorm       = "storm"        // storm protection notice
+	MessageKeyFlapping    = "flapping"     // process flapping alarm
+	MessageKeyDowntime    = "downtime"     // restarted message (shutdown and startup merged)
+	MessageKeyDigest      = "digest"       // count of messages held during quiet hours
+	MessageKeyPage        = "page"         // id of on-call page
+	MessageKeyAlarmId     = "alarm_id"     // id of alarm lifecycle
+)
+
+// reservedMessageKeys are keys of message defined by fatima-core or saturn
+var reservedMessageKeys = map[string]bool{
+	MessageKeyType: true, MessageKeyAction: true, MessageKeyAlarmLevel: true, MessageKeyCategory: true,
+	MessageKeyMessage: true, MessageKeyDeployment: true, MessageKeyTimestamp: true, MessageKeyFrom: true,
+	MessageKeyInitiator: true, MessageKeyParams: true, MessageKeyRepeatCount: true, MessageKeyStorm: true,
+	MessageKeyFlapping: true, MessageKeyDowntime: true, MessageKeyDigest: true, MessageKeyPage: true,
+	MessageKeyAlarmId: true,
+}
+
+// IsReservedMessageKey returns true when the key is defined by fatima-core or saturn
+func IsReservedMessageKey(key string) bool {
+	return reservedMessageKeys[key]
+}
+
+const (
+	FieldProfile    = "profile"
+	FieldGroup      = "group"
+	FieldHost       = "host"
+	FieldName       = "name"
+	FieldProcess    = "process"
+	FieldAlarmLevel = "alarm_level"
+	FieldType       = "type"
+	FieldAction     = "action"
+	FieldCategory   = "category"
+	FieldMessage    = "message"
+)
+
 const (
 	NotifyAlarm           = "ALARM"
+	NotifyEvent           = "EVENT"
 	ActionProcessStartup  = "PROCESS_STARTUP"
 	ActionProcessShutdown = "PROCESS_SHUTDOWN"
 )
@@ -129,6 +173,68 @@ func (m MBusMessageBody) IsProcessStartupOrShutdown() bool {
 	return m.IsProcessStartup() || m.IsProcessShutdown()
 }
 
+func (m MBusMessageBody) GetAlarmLevel() string {
+	if s, ok := m.Message[MessageKeyAlarmLevel].(string); ok {
+		return s
+	}
+	return ""
+}
+
+func (m MBusMessageBody) GetType() string {
+	if s, ok := m.Message[MessageKeyType].(string); ok {
+		return s
+	}
+	return ""
+}
+
+func (m MBusMessageBody) GetAction() string {
+	if s, ok := m.Message[MessageKeyAction].(string); ok {
+		return s
+	}
+	return ""
+}
+
+// IsRepeatSummary returns true when the message is a summary of repeated messages
+func (m MBusMessageBody) IsRepeatSummary() bool {
+	_, ok := m.Message[MessageKeyRepeatCount]
+	return ok
+}
+
+// IsSummary returns true when the message is a summary of other messages (repeat, storm or digest)
+func (m MBusMessageBody) IsSummary() bool {
+	if m.IsRepeatSummary() {
+		return true
+	}
+	_, storm := m.Message[MessageKeyStorm]
+	_, digest := m.Message[MessageKeyDigest]
+	return storm || digest
+}
+
+// GetAlarmId returns id of alarm lifecycle which saturn assigned
+func (m MBusMessageBody) GetAlarmId() string {
+	if s, ok := m.Message[MessageKeyAlarmId].(string); ok {
+		return s
+	}
+	return ""
+}
+
+func (m MBusMessageBody) IsMajorAlarm() bool {
+	return m.IsAlarm() && m.GetAlarmLevel() == AlarmLevelMajor
+}
+
+// GetText returns raw message text without any decoration
+func (m MBusMessageBody) GetText() string {
+	if s, ok := m.Message[MessageKeyMessage].(string); ok {
+		return s
+	}
+	return ""
+}
+
+// GetSourceKey returns group:host:process which identifies the message sender
+func (m MBusMessageBody) GetSourceKey() string {
+	return fmt.Sprintf("%s:%s:%s", m.PackageGroup, m.PackageHost, m.PackageProcess)
+}
+
 func (m MBusMessageBody) GetCategory() string {
 	category, ok := m.Message[MessageKeyCategory]
 	if !ok {
@@ -163,6 +269,60 @@ func (m MBusMessageBody) GetHashsum() string {
 	return fmt.Sprintf("%x", hashing.Sum(nil))
 }
 
+// Clone returns copy of the message which does not share Message map
+func (m MBusMessageBody) Clone() MBusMessageBody {
+	c := m
+	c.Message = make(map[string]interface{}, len(m.Message))
+	for k, v := range m.Message {
+		c.Message[k] = v
+	}
+	return c
+}
+
+// GetFieldValue returns value of message field (e.g. FieldProcess, FieldAlarmLevel)
+func (m MBusMessageBody) GetFieldValue(field string) (string, bool) {
+	switch field {
+	case FieldProfile:
+		return m.PackageProfile, true
+	case FieldGroup:
+		return m.PackageGroup, true
+	case FieldHost:
+		return m.PackageHost, true
+	case FieldName:
+		return m.PackageName, true
+	case FieldProcess:
+		return m.PackageProcess, true
+	case FieldAlarmLevel:
+		return m.GetAlarmLevel(), true
+	case FieldType:
+		return m.GetType(), true
+	case FieldAction:
+		return m.GetAction(), true
+	case FieldCategory:
+		return m.GetCategory(), true
+	case FieldMessage:
+		return m.GetText(), true
+	}
+	return "", false
+}
+
+// GetFingerprint returns hashsum of given fields
+// every text part matched with masks is replaced before hashing (e.g. [0-9]+ for changing numbers)
+func (m MBusMessageBody) GetFingerprint(fields []string, masks []*regexp.Regexp) string {
+	hashing := sha256.New()
+	for _, field := range fields {
+		value, _ := m.GetFieldValue(field)
+		if field == FieldMessage {
+			for _, re := range masks {
+				value = re.ReplaceAllString(value, "*")
+			}
+		}
+		hashing.Write([]byte(value))
+		hashing.Write([]byte{'.'})
+	}
+	return fmt.Sprintf("%x", hashing.Sum(nil))
+}
+
 func (m MBusMessageBody) GetDeployment() Deployment {
 	deployment := Deployment{Valid: false}
 
@@ -0,0 +1,39 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 22. 오후 4:10
+ */
+
+package domain
+
+// Page is on-call notification of MAJOR alarm which escalates until someone acknowledges it
+// time values are unix millis
+type Page struct {
+	Id             string          `json:"id"`
+	Policy         string          `json:"policy"`
+	Level          int             `json:"level"`   // current escalation level (0 based)
+	Oncall         string          `json:"oncall"`  // member paged at current level
+	Message        MBusMessageBody `json:"message"` // alarm which caused the page
+	CreatedAt      int             `json:"created_at"`
+	EscalatedAt    int             `json:"escalated_at"`
+	AcknowledgedBy string          `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt int             `json:"acknowledged_at,omitempty"`
+}
+
+func (p Page) IsAcknowledged() bool {
+	return p.AcknowledgedAt > 0
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 21. 오후 3:00
+ */
+
+package domain
+
+// Silence drops matched messages between StartsAt and EndsAt (unix millis)
+type Silence struct {
+	Id        string         `json:"id"`
+	Matcher   MessageMatcher `json:"matcher"`
+	StartsAt  int            `json:"starts_at"`
+	EndsAt    int            `json:"ends_at"`
+	CreatedBy string         `json:"created_by"`
+	Comment   string         `json:"comment,omitempty"`
+	CreatedAt int            `json:"created_at"`
+}
+
+func (s Silence) IsActive(now int) bool {
+	return s.StartsAt <= now && now < s.EndsAt
+}
+
+func (s Silence) IsExpired(now int) bool {
+	return s.EndsAt <= now
+}
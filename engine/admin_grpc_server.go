@@ -0,0 +1,152 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 29. 오후 1:30
+ */
+
+package engine
+
+import (
+	"context"
+	"crypto/subtle"
+	"net"
+	"strings"
+
+	"github.com/fatima-go/fatima-core"
+	"github.com/fatima-go/fatima-log"
+	admin "github.com/fatima-go/saturn/proto/saturn.admin.v1"
+	"github.com/fatima-go/saturn/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	propAdminListen     = "saturn.admin.listen"
+	propAdminToken      = "saturn.admin.token"
+	metadataAuthorize   = "authorization"
+	authorizationBearer = "Bearer "
+)
+
+func NewAdminGrpcServer(fatimaRuntime fatima.FatimaRuntime, applicationExecutor service.ApplicationExecutor) *AdminGrpcServer {
+	server := new(AdminGrpcServer)
+	server.fatimaRuntime = fatimaRuntime
+	server.applicationExecutor = applicationExecutor
+	return server
+}
+
+// AdminGrpcServer serves SaturnAdminService on its own address apart from message ingest
+// it is enabled only when saturn.admin.listen is specified. when saturn.admin.token is specified,
+// every call should have "authorization: Bearer <token>" metadata
+type AdminGrpcServer struct {
+	fatimaRuntime       fatima.FatimaRuntime
+	applicationExecutor service.ApplicationExecutor
+	token               string
+	server              *grpc.Server
+}
+
+func (a *AdminGrpcServer) Initialize() bool {
+	log.Info("AdminGrpcServer Initialize()")
+
+	address, err := a.fatimaRuntime.GetConfig().GetString(propAdminListen)
+	if err != nil || len(address) == 0 {
+		log.Info("saturn admin service is disabled")
+		return true
+	}
+
+	if token, err := a.fatimaRuntime.GetConfig().GetString(propAdminToken); err == nil {
+		a.token = token
+	}
+	if len(a.token) == 0 {
+		log.Warn("saturn admin service has no token. every client which can reach %s is allowed", address)
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		log.Warn("failed to listen: %v", err)
+		return false
+	}
+
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(a.authorizeUnary, unaryInterceptor),
+		grpc.ChainStreamInterceptor(a.authorizeStream, streamInterceptor),
+	}
+	a.server = grpc.NewServer(opts...)
+	admin.RegisterSaturnAdminServiceServer(a.server, newAdminServer(a.applicationExecutor))
+	reflection.Register(a.server)
+
+	log.Info("saturn admin server start. address=%v", address)
+	go func() {
+		if err := a.server.Serve(listener); err != nil {
+			log.Warn("saturn admin serving error : %s", err.Error())
+		}
+	}()
+	return true
+}
+
+func (a *AdminGrpcServer) Bootup() {
+	log.Info("AdminGrpcServer Bootup()")
+}
+
+func (a *AdminGrpcServer) Goaway() {
+}
+
+func (a *AdminGrpcServer) Shutdown() {
+	log.Info("AdminGrpcServer Shutdown()")
+	if a.server == nil {
+		return
+	}
+	a.server.Stop()
+}
+
+func (a *AdminGrpcServer) GetType() fatima.FatimaComponentType {
+	return fatima.COMP_READER
+}
+
+func (a *AdminGrpcServer) authorizeUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := a.authorize(ctx); err != nil {
+		log.Warn("%s : %s", info.FullMethod, err.Error())
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *AdminGrpcServer) authorizeStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := a.authorize(ss.Context()); err != nil {
+		log.Warn("%s : %s", info.FullMethod, err.Error())
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// authorize checks bearer token of metadata when token is configured
+func (a *AdminGrpcServer) authorize(ctx context.Context) error {
+	if len(a.token) == 0 {
+		return nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get(metadataAuthorize) {
+		token := strings.TrimPrefix(v, authorizationBearer)
+		if subtle.ConstantTimeCompare([]byte(token), []byte(a.token)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "invalid admin token")
+}
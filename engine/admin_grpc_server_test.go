@@ -0,0 +1,58 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 29. 오후 1:50
+ */
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAdminAuthorize(t *testing.T) {
+	server := &AdminGrpcServer{}
+	if err := server.authorize(context.Background()); err != nil {
+		t.Fatalf("every call should be allowed without token : %s", err.Error())
+	}
+
+	server.token = "secret"
+	tests := []struct {
+		name  string
+		ctx   context.Context
+		allow bool
+	}{
+		{"no metadata", context.Background(), false},
+		{"wrong token", metadata.NewIncomingContext(context.Background(), metadata.Pairs(metadataAuthorize, "Bearer other")), false},
+		{"bearer token", metadata.NewIncomingContext(context.Background(), metadata.Pairs(metadataAuthorize, "Bearer secret")), true},
+	}
+
+	for _, tt := range tests {
+		err := server.authorize(tt.ctx)
+		if tt.allow && err != nil {
+			t.Fatalf("%s : should be allowed : %s", tt.name, err.Error())
+		}
+		if !tt.allow && status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("%s : should be unauthenticated : %v", tt.name, err)
+		}
+	}
+}
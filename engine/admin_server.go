@@ -0,0 +1,322 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 21. 오후 3:00
+ */
+
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	log "github.com/fatima-go/fatima-log"
+	"github.com/fatima-go/saturn/domain"
+	admin "github.com/fatima-go/saturn/proto/saturn.admin.v1"
+	"github.com/fatima-go/saturn/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newAdminServer(applicationExecutor service.ApplicationExecutor) *AdminServer {
+	server := new(AdminServer)
+	server.applicationExecutor = applicationExecutor
+	return server
+}
+
+// AdminServer serves saturn administration (silence, on-call page, alarm, ...) on AdminGrpcServer
+type AdminServer struct {
+	applicationExecutor service.ApplicationExecutor
+	admin.UnimplementedSaturnAdminServiceServer
+}
+
+func (a *AdminServer) CreateSilence(ctx context.Context, request *admin.CreateSilenceRequest) (*admin.CreateSilenceResponse, error) {
+	if request.GetSilence() == nil {
+		return nil, status.Error(codes.InvalidArgument, "silence is required")
+	}
+
+	silence := toDomainSilence(request.GetSilence())
+	if silence.EndsAt == 0 && request.GetDurationSeconds() > 0 {
+		if silence.StartsAt == 0 {
+			silence.StartsAt = int(time.Now().UnixMilli())
+		}
+		silence.EndsAt = silence.StartsAt + int(request.GetDurationSeconds())*1000
+	}
+
+	created, err := a.applicationExecutor.CreateSilence(silence)
+	if err != nil {
+		return nil, toStatusError("CreateSilence", err)
+	}
+	return &admin.CreateSilenceResponse{Silence: toProtoSilence(created)}, nil
+}
+
+func (a *AdminServer) ListSilences(ctx context.Context, request *admin.ListSilencesRequest) (*admin.ListSilencesResponse, error) {
+	response := &admin.ListSilencesResponse{}
+	for _, v := range a.applicationExecutor.ListSilences(request.GetIncludeExpired()) {
+		response.Silences = append(response.Silences, toProtoSilence(v))
+	}
+	return response, nil
+}
+
+func (a *AdminServer) ExpireSilence(ctx context.Context, request *admin.ExpireSilenceRequest) (*admin.ExpireSilenceResponse, error) {
+	expired, err := a.applicationExecutor.ExpireSilence(request.GetId())
+	if err != nil {
+		return nil, toStatusError("ExpireSilence", err)
+	}
+	return &admin.ExpireSilenceResponse{Silence: toProtoSilence(expired)}, nil
+}
+
+func (a *AdminServer) ListPages(ctx context.Context, request *admin.ListPagesRequest) (*admin.ListPagesResponse, error) {
+	response := &admin.ListPagesResponse{}
+	for _, v := range a.applicationExecutor.ListPages(request.GetIncludeAcknowledged()) {
+		response.Pages = append(response.Pages, toProtoPage(v))
+	}
+	return response, nil
+}
+
+func (a *AdminServer) AcknowledgePage(ctx context.Context, request *admin.AcknowledgePageRequest) (*admin.AcknowledgePageResponse, error) {
+	page, err := a.applicationExecutor.AcknowledgePage(request.GetId(), request.GetAcknowledgedBy())
+	if err != nil {
+		return nil, toStatusError("AcknowledgePage", err)
+	}
+	return &admin.AcknowledgePageResponse{Page: toProtoPage(page)}, nil
+}
+
+func (a *AdminServer) ListAlarms(ctx context.Context, request *admin.ListAlarmsRequest) (*admin.ListAlarmsResponse, error) {
+	response := &admin.ListAlarmsResponse{}
+	for _, v := range a.applicationExecutor.ListAlarms(request.GetStates()) {
+		response.Alarms = append(response.Alarms, toProtoAlarm(v))
+	}
+	return response, nil
+}
+
+func (a *AdminServer) AcknowledgeAlarm(ctx context.Context, request *admin.ChangeAlarmRequest) (*admin.ChangeAlarmResponse, error) {
+	alarm, err := a.applicationExecutor.AcknowledgeAlarm(request.GetId(), request.GetUpdatedBy(), request.GetComment())
+	if err != nil {
+		return nil, toStatusError("AcknowledgeAlarm", err)
+	}
+	return &admin.ChangeAlarmResponse{Alarm: toProtoAlarm(alarm)}, nil
+}
+
+func (a *AdminServer) ResolveAlarm(ctx context.Context, request *admin.ChangeAlarmRequest) (*admin.ChangeAlarmResponse, error) {
+	alarm, err := a.applicationExecutor.ResolveAlarm(request.GetId(), request.GetUpdatedBy(), request.GetComment())
+	if err != nil {
+		return nil, toStatusError("ResolveAlarm", err)
+	}
+	return &admin.ChangeAlarmResponse{Alarm: toProtoAlarm(alarm)}, nil
+}
+
+func (a *AdminServer) ListDeadLetters(ctx context.Context, request *admin.ListDeadLettersRequest) (*admin.ListDeadLettersResponse, error) {
+	response := &admin.ListDeadLettersResponse{}
+	for _, v := range a.applicationExecutor.ListDeadLetters(request.GetNotifier()) {
+		response.DeadLetters = append(response.DeadLetters, toProtoDeadLetter(v))
+	}
+	return response, nil
+}
+
+func (a *AdminServer) GetDeadLetter(ctx context.Context, request *admin.GetDeadLetterRequest) (*admin.GetDeadLetterResponse, error) {
+	letter, err := a.applicationExecutor.GetDeadLetter(request.GetId())
+	if err != nil {
+		return nil, toStatusError("GetDeadLetter", err)
+	}
+	return &admin.GetDeadLetterResponse{DeadLetter: toProtoDeadLetter(letter)}, nil
+}
+
+func (a *AdminServer) RedeliverDeadLetter(ctx context.Context, request *admin.RedeliverDeadLetterRequest) (*admin.RedeliverDeadLetterResponse, error) {
+	letter, err := a.applicationExecutor.RedeliverDeadLetter(request.GetId())
+	if err != nil {
+		return nil, toStatusError("RedeliverDeadLetter", err)
+	}
+	return &admin.RedeliverDeadLetterResponse{DeadLetter: toProtoDeadLetter(letter)}, nil
+}
+
+func (a *AdminServer) PurgeDeadLetters(ctx context.Context, request *admin.PurgeDeadLettersRequest) (*admin.PurgeDeadLettersResponse, error) {
+	purged, err := a.applicationExecutor.PurgeDeadLetters(request.GetIds(), request.GetNotifier(), request.GetAll())
+	if err != nil {
+		return nil, toStatusError("PurgeDeadLetters", err)
+	}
+	return &admin.PurgeDeadLettersResponse{Purged: int32(purged)}, nil
+}
+
+func (a *AdminServer) Subscribe(request *admin.SubscribeRequest, stream admin.SaturnAdminService_SubscribeServer) error {
+	matchers := make([]domain.MessageMatcher, 0, len(request.GetMatchers()))
+	for _, v := range request.GetMatchers() {
+		matchers = append(matchers, toDomainMatcher(v))
+	}
+
+	decisions, cancel, err := a.applicationExecutor.Subscribe(matchers)
+	if err != nil {
+		return toStatusError("Subscribe", err)
+	}
+	defer cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case decision := <-decisions:
+			if err = stream.Send(toProtoDecision(decision)); err != nil {
+				log.Info("Subscribe closed : %s", err.Error())
+				return err
+			}
+		}
+	}
+}
+
+func (a *AdminServer) QueryAlarms(ctx context.Context, request *admin.QueryAlarmsRequest) (*admin.QueryAlarmsResponse, error) {
+	query := service.HistoryQuery{
+		From:      int(request.GetFrom()),
+		To:        int(request.GetTo()),
+		Text:      request.GetText(),
+		Results:   request.GetResults(),
+		PageSize:  int(request.GetPageSize()),
+		PageToken: request.GetPageToken(),
+	}
+	for _, v := range request.GetMatchers() {
+		query.Matchers = append(query.Matchers, toDomainMatcher(v))
+	}
+
+	decisions, nextPageToken, err := a.applicationExecutor.QueryAlarms(query)
+	if err != nil {
+		return nil, toStatusError("QueryAlarms", err)
+	}
+
+	response := &admin.QueryAlarmsResponse{NextPageToken: nextPageToken}
+	for _, v := range decisions {
+		response.Decisions = append(response.Decisions, toProtoDecision(v))
+	}
+	return response, nil
+}
+
+func toStatusError(method string, err error) error {
+	log.Warn("%s error : %s", method, err.Error())
+	switch {
+	case errors.Is(err, service.ErrInvalidParameter):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func toDomainMatcher(m *admin.MessageMatcher) domain.MessageMatcher {
+	return domain.MessageMatcher{
+		Profile:    m.GetProfile(),
+		Group:      m.GetGroup(),
+		Host:       m.GetHost(),
+		Process:    m.GetProcess(),
+		AlarmLevel: m.GetAlarmLevel(),
+		Type:       m.GetType(),
+		Action:     m.GetAction(),
+		Category:   m.GetCategory(),
+		Message:    m.GetMessage(),
+	}
+}
+
+func toProtoMatcher(m domain.MessageMatcher) *admin.MessageMatcher {
+	return &admin.MessageMatcher{
+		Profile:    m.Profile,
+		Group:      m.Group,
+		Host:       m.Host,
+		Process:    m.Process,
+		AlarmLevel: m.AlarmLevel,
+		Type:       m.Type,
+		Action:     m.Action,
+		Category:   m.Category,
+		Message:    m.Message,
+	}
+}
+
+func toDomainSilence(s *admin.Silence) domain.Silence {
+	return domain.Silence{
+		Matcher:   toDomainMatcher(s.GetMatcher()),
+		StartsAt:  int(s.GetStartsAt()),
+		EndsAt:    int(s.GetEndsAt()),
+		CreatedBy: s.GetCreatedBy(),
+		Comment:   s.GetComment(),
+	}
+}
+
+func toProtoSilence(s domain.Silence) *admin.Silence {
+	return &admin.Silence{
+		Id:        s.Id,
+		Matcher:   toProtoMatcher(s.Matcher),
+		StartsAt:  int64(s.StartsAt),
+		EndsAt:    int64(s.EndsAt),
+		CreatedBy: s.CreatedBy,
+		Comment:   s.Comment,
+		CreatedAt: int64(s.CreatedAt),
+	}
+}
+
+func toProtoPage(p domain.Page) *admin.Page {
+	b, _ := json.Marshal(p.Message)
+	return &admin.Page{
+		Id:             p.Id,
+		Policy:         p.Policy,
+		Level:          int32(p.Level),
+		Oncall:         p.Oncall,
+		JsonString:     string(b),
+		CreatedAt:      int64(p.CreatedAt),
+		EscalatedAt:    int64(p.EscalatedAt),
+		AcknowledgedBy: p.AcknowledgedBy,
+		AcknowledgedAt: int64(p.AcknowledgedAt),
+	}
+}
+
+func toProtoAlarm(a domain.Alarm) *admin.Alarm {
+	b, _ := json.Marshal(a.Message)
+	return &admin.Alarm{
+		Id:         a.Id,
+		State:      a.State,
+		JsonString: string(b),
+		Count:      int32(a.Count),
+		FiredAt:    int64(a.FiredAt),
+		LastAt:     int64(a.LastAt),
+		UpdatedAt:  int64(a.UpdatedAt),
+		UpdatedBy:  a.UpdatedBy,
+		Comment:    a.Comment,
+		Notified:   a.Notified,
+	}
+}
+
+func toProtoDeadLetter(d domain.Delivery) *admin.DeadLetter {
+	b, _ := json.Marshal(d.Message)
+	return &admin.DeadLetter{
+		Id:            d.Id,
+		Notifier:      d.Notifier,
+		JsonString:    string(b),
+		CreatedAt:     int64(d.CreatedAt),
+		Attempts:      int32(d.Attempts),
+		LastError:     d.LastError,
+		LastAttemptAt: int64(d.LastAttemptAt),
+	}
+}
+
+func toProtoDecision(d domain.Decision) *admin.MessageDecision {
+	b, _ := json.Marshal(d.Message)
+	return &admin.MessageDecision{
+		JsonString: string(b),
+		Result:     d.Result,
+		SilenceId:  d.SilenceId,
+		Notified:   d.Notified,
+		DecidedAt:  int64(d.DecidedAt),
+		Id:         d.Id,
+	}
+}
@@ -23,16 +23,17 @@ package engine
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/fatima-go/fatima-core"
 	"github.com/fatima-go/fatima-core/builder"
 	proto "github.com/fatima-go/fatima-core/builder/fatima.message.v1"
 	"github.com/fatima-go/fatima-log"
 	"github.com/fatima-go/saturn/domain"
+	message "github.com/fatima-go/saturn/proto/saturn.message.v1"
 	"github.com/fatima-go/saturn/service"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/peer"
-	"google.golang.org/grpc/reflection"
 	"net"
 	"sync/atomic"
 	"time"
@@ -55,7 +56,8 @@ type GrpcServer struct {
 	applicationExecutor service.ApplicationExecutor
 	listener            net.Listener
 	server              *grpc.Server
-	proto.UnimplementedFatimaMessageServiceServer
+	messageServer       *MessageServer
+	message.UnimplementedFatimaMessageServiceServer
 }
 
 func (g *GrpcServer) Initialize() bool {
@@ -74,13 +76,15 @@ func (g *GrpcServer) Initialize() bool {
 
 	// create server
 	// Create an array of gRPC options with the credentials
-	opts := []grpc.ServerOption{grpc.UnaryInterceptor(unaryInterceptor)}
+	opts := []grpc.ServerOption{grpc.UnaryInterceptor(unaryInterceptor), grpc.StreamInterceptor(streamInterceptor)}
 	g.server = grpc.NewServer(opts...)
 
 	// regist controllers
-	proto.RegisterFatimaMessageServiceServer(g.server, g)
+	g.messageServer = newMessageServer(g.consume, g.accept)
+	message.RegisterFatimaMessageServiceServer(g.server, g)
+	message.RegisterSaturnMessageServiceServer(g.server, g.messageServer)
 
-	reflection.Register(g.server)
+	registerReflection(g.server)
 
 	go func() {
 		err = g.server.Serve(g.listener)
@@ -97,6 +101,7 @@ func (g *GrpcServer) Initialize() bool {
 
 func (g *GrpcServer) Bootup() {
 	log.Info("GrpcServer Bootup()")
+	g.applicationExecutor.ReplayOutbox()
 }
 
 func (g *GrpcServer) Goaway() {
@@ -126,24 +131,37 @@ func (g *GrpcServer) buildServiceAddress() string {
 }
 
 func (g *GrpcServer) SendFatimaMessage(ctx context.Context, request *proto.SendFatimaMessageRequest) (*proto.SendFatimaMessageResponse, error) {
-	response := &proto.SendFatimaMessageResponse{}
-	var err error
-	g.consume(request.GetJsonString())
+	err := g.consume(request.GetJsonString())
 	if err != nil {
-		response.Response = &proto.SendFatimaMessageResponse_Error{Error: buildInternalServerError(err)}
-
 		log.Warn("SendFatimaMessage error : %s", err.Error())
-		return response, nil
 	}
+	return buildSendResponse(err), nil
+}
 
-	response.Response = &proto.SendFatimaMessageResponse_Success{Success: &proto.ResponseSuccess{}}
-	return response, nil
+func (g *GrpcServer) SendFatimaMessages(ctx context.Context, request *message.SendFatimaMessagesRequest) (*message.SendFatimaMessagesResponse, error) {
+	return g.messageServer.SendFatimaMessages(ctx, request)
+}
 
+func (g *GrpcServer) StreamFatimaMessages(stream message.FatimaMessageService_StreamFatimaMessagesServer) error {
+	return g.messageServer.StreamFatimaMessages(stream)
+}
+
+func buildSendResponse(err error) *proto.SendFatimaMessageResponse {
+	response := &proto.SendFatimaMessageResponse{}
+	if err != nil {
+		response.Response = &proto.SendFatimaMessageResponse_Error{Error: buildResponseError(err)}
+		return response
+	}
+
+	response.Response = &proto.SendFatimaMessageResponse_Success{Success: &proto.ResponseSuccess{}}
+	return response
 }
 
-func (g *GrpcServer) consume(jsonString string) {
+// consume returns service.ErrInvalidParameter for malformed json, service.ErrNotAcceptable for message of
+// other application and service.ErrQueueFull when the message could not be accepted now (all wrapped)
+func (g *GrpcServer) consume(jsonString string) error {
 	if len(jsonString) < 3 {
-		return
+		return fmt.Errorf("%w : too short message (%d)", service.ErrInvalidParameter, len(jsonString))
 	}
 
 	if log.IsTraceEnabled() {
@@ -154,15 +172,41 @@ func (g *GrpcServer) consume(jsonString string) {
 	var message domain.MBusMessage
 	err := json.Unmarshal(data, &message)
 	if err != nil {
-		log.Warn("fail to unmarshal : %s", err.Error())
-		return
+		return fmt.Errorf("%w : fail to unmarshal : %s", service.ErrInvalidParameter, err.Error())
 	}
 
+	return g.accept(message)
+}
+
+// accept passes message of fatima application to executor
+func (g *GrpcServer) accept(message domain.MBusMessage) error {
 	if message.Header.ApplicationCode != builder.ApplicationCode {
-		return
+		return fmt.Errorf("%w : application code %d", service.ErrNotAcceptable, message.Header.ApplicationCode)
 	}
 
-	g.applicationExecutor.Consume(message)
+	return g.applicationExecutor.Consume(message)
+}
+
+// buildResponseError maps error of consume to ResponseError
+func buildResponseError(err error) *proto.ResponseError {
+	switch {
+	case errors.Is(err, service.ErrInvalidParameter):
+		return &proto.ResponseError{
+			GrpcResponse: proto.ResponseError_BAD_PARAMETER,
+			Code:         proto.ResponseError_ERROR_ETC,
+			Value:        fmt.Sprintf("Bad Parameter : %s", err.Error())}
+	case errors.Is(err, service.ErrNotAcceptable):
+		return &proto.ResponseError{
+			GrpcResponse: proto.ResponseError_NOT_ACCEPTABLE,
+			Code:         proto.ResponseError_ERROR_ETC,
+			Value:        fmt.Sprintf("Not Acceptable : %s", err.Error())}
+	case errors.Is(err, service.ErrQueueFull):
+		return &proto.ResponseError{
+			GrpcResponse: proto.ResponseError_SERVICE_UNAVAILABLE,
+			Code:         proto.ResponseError_ERROR_ETC,
+			Value:        fmt.Sprintf("Service Unavailable : %s", err.Error())}
+	}
+	return buildInternalServerError(err)
 }
 
 func buildInternalServerError(err error) *proto.ResponseError {
@@ -186,6 +230,18 @@ func unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServ
 	return handler(ctx, req)
 }
 
+// streamInterceptor logs stream with transaction id
+func streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	var remoteAddress string
+	p, ok := peer.FromContext(ss.Context())
+	if ok {
+		remoteAddress = p.Addr.String()
+	}
+	transactionId := fmt.Sprintf("CSTM%06d", nextCustomTransactionId())
+	log.Debug("<=== [%12s] === : [%s] %s", transactionId, remoteAddress, info.FullMethod)
+	return handler(srv, ss)
+}
+
 var ops uint64
 
 func nextCustomTransactionId() uint64 {
@@ -0,0 +1,242 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 27. 오전 10:15
+ */
+
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/fatima-go/fatima-core/builder"
+	proto "github.com/fatima-go/fatima-core/builder/fatima.message.v1"
+	"github.com/fatima-go/fatima-core/lib"
+	log "github.com/fatima-go/fatima-log"
+	"github.com/fatima-go/saturn/domain"
+	message "github.com/fatima-go/saturn/proto/saturn.message.v1"
+	"github.com/fatima-go/saturn/service"
+)
+
+func newMessageServer(consume func(jsonString string) error, accept func(mbus domain.MBusMessage) error) *MessageServer {
+	server := new(MessageServer)
+	server.consume = consume
+	server.accept = accept
+	return server
+}
+
+// MessageServer serves batch and streaming ingest of FatimaMessageService and typed ingest of SaturnMessageService.
+// each message is consumed the same as SendFatimaMessage and has its own result
+type MessageServer struct {
+	consume func(jsonString string) error
+	accept  func(mbus domain.MBusMessage) error
+	message.UnimplementedSaturnMessageServiceServer
+}
+
+func (m *MessageServer) SendFatimaMessages(ctx context.Context, request *message.SendFatimaMessagesRequest) (*message.SendFatimaMessagesResponse, error) {
+	response := &message.SendFatimaMessagesResponse{}
+	for _, v := range request.GetMessages() {
+		m.add(response, v)
+	}
+	return response, nil
+}
+
+func (m *MessageServer) StreamFatimaMessages(stream message.FatimaMessageService_StreamFatimaMessagesServer) error {
+	response := &message.SendFatimaMessagesResponse{}
+	for {
+		request, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(response)
+		}
+		if err != nil {
+			log.Warn("StreamFatimaMessages error : %s", err.Error())
+			return err
+		}
+		m.add(response, request)
+	}
+}
+
+func (m *MessageServer) add(response *message.SendFatimaMessagesResponse, request *proto.SendFatimaMessageRequest) {
+	err := m.consume(request.GetJsonString())
+	if err != nil {
+		response.Rejected++
+		log.Warn("SendFatimaMessages error : %s", err.Error())
+	} else {
+		response.Accepted++
+	}
+	response.Results = append(response.Results, buildSendResponse(err))
+}
+
+// SendMessage accepts typed message. json string is used as fallback
+func (m *MessageServer) SendMessage(ctx context.Context, request *message.SendMessageRequest) (*proto.SendFatimaMessageResponse, error) {
+	var err error
+	if typed := request.GetMessage(); typed != nil {
+		err = m.send(typed)
+	} else {
+		err = m.consume(request.GetJsonString())
+	}
+	if err != nil {
+		log.Warn("SendMessage error : %s", err.Error())
+	}
+	return buildSendResponse(err), nil
+}
+
+func (m *MessageServer) send(typed *message.FatimaMessage) error {
+	mbus, err := toDomainMessage(typed)
+	if err != nil {
+		return err
+	}
+
+	if log.IsTraceEnabled() {
+		log.Trace("%v", mbus)
+	}
+	return m.accept(mbus)
+}
+
+// toDomainMessage converts typed message to the same message as json message of fatima-core
+func toDomainMessage(typed *message.FatimaMessage) (domain.MBusMessage, error) {
+	mbus := domain.MBusMessage{}
+	body := typed.GetBody()
+	if body == nil || body.GetContent() == nil {
+		return mbus, fmt.Errorf("%w : body content is required", service.ErrInvalidParameter)
+	}
+
+	mbus.Header.ApplicationCode = int(typed.GetHeader().GetApplicationCode())
+	if mbus.Header.ApplicationCode == 0 {
+		mbus.Header.ApplicationCode = builder.ApplicationCode
+	}
+	mbus.Header.Logic = int(typed.GetHeader().GetLogic())
+	if mbus.Header.Logic == 0 {
+		mbus.Header.Logic = builder.LogicNotify
+	}
+
+	mbus.Body.EventTime = int(body.GetEventTime())
+	if mbus.Body.EventTime == 0 {
+		mbus.Body.EventTime = lib.CurrentTimeMillis()
+	}
+	mbus.Body.PackageGroup = body.GetPackageGroup()
+	mbus.Body.PackageHost = body.GetPackageHost()
+	mbus.Body.PackageName = body.GetPackageName()
+	mbus.Body.PackageProcess = body.GetPackageProcess()
+	mbus.Body.PackageProfile = body.GetPackageProfile()
+
+	content, err := toDomainContent(body.GetContent())
+	if err != nil {
+		return mbus, err
+	}
+	mbus.Body.Message = content
+	return mbus, nil
+}
+
+func toDomainContent(c *message.NotifyContent) (map[string]interface{}, error) {
+	content := make(map[string]interface{}, len(c.GetExtra())+10)
+	for k, v := range c.GetExtra() {
+		if domain.IsReservedMessageKey(k) {
+			return nil, fmt.Errorf("%w : extra key %s is reserved", service.ErrInvalidParameter, k)
+		}
+		content[k] = v
+	}
+
+	switch c.GetType() {
+	case message.NotifyType_NOTIFY_TYPE_ALARM:
+		content[domain.MessageKeyType] = domain.NotifyAlarm
+	case message.NotifyType_NOTIFY_TYPE_EVENT:
+		content[domain.MessageKeyType] = domain.NotifyEvent
+	default:
+		return nil, fmt.Errorf("%w : unknown notify type %s", service.ErrInvalidParameter, c.GetType())
+	}
+
+	switch c.GetAlarmLevel() {
+	case message.AlarmLevel_ALARM_LEVEL_UNSPECIFIED:
+	case message.AlarmLevel_ALARM_LEVEL_WARN:
+		content[domain.MessageKeyAlarmLevel] = domain.AlarmLevelWarn
+	case message.AlarmLevel_ALARM_LEVEL_MINOR:
+		content[domain.MessageKeyAlarmLevel] = domain.AlarmLevelMinor
+	case message.AlarmLevel_ALARM_LEVEL_MAJOR:
+		content[domain.MessageKeyAlarmLevel] = domain.AlarmLevelMajor
+	default:
+		return nil, fmt.Errorf("%w : unknown alarm level %s", service.ErrInvalidParameter, c.GetAlarmLevel())
+	}
+
+	switch c.GetAction() {
+	case message.Action_ACTION_UNSPECIFIED:
+	case message.Action_ACTION_PROCESS_STARTUP:
+		content[domain.MessageKeyAction] = domain.ActionProcessStartup
+	case message.Action_ACTION_PROCESS_SHUTDOWN:
+		content[domain.MessageKeyAction] = domain.ActionProcessShutdown
+	default:
+		return nil, fmt.Errorf("%w : unknown action %s", service.ErrInvalidParameter, c.GetAction())
+	}
+
+	setIfNotEmpty(content, domain.MessageKeyCategory, c.GetCategory())
+	setIfNotEmpty(content, domain.MessageKeyTimestamp, c.GetTimestamp())
+	setIfNotEmpty(content, domain.MessageKeyFrom, c.GetFrom())
+	setIfNotEmpty(content, domain.MessageKeyInitiator, c.GetInitiator())
+	content[domain.MessageKeyMessage] = c.GetMessage()
+	if len(c.GetParams()) > 0 {
+		params := make([]interface{}, 0, len(c.GetParams()))
+		for _, v := range c.GetParams() {
+			params = append(params, v)
+		}
+		content[domain.MessageKeyParams] = params
+	}
+	if d := c.GetDeployment(); d != nil {
+		deployment, err := toDomainDeployment(d)
+		if err != nil {
+			return nil, err
+		}
+		content[domain.MessageKeyDeployment] = deployment
+	}
+	return content, nil
+}
+
+// toDomainDeployment returns deployment in the same form as json message has (map)
+func toDomainDeployment(d *message.Deployment) (map[string]interface{}, error) {
+	deployment := domain.Deployment{
+		Process:     d.GetProcess(),
+		ProcessType: d.GetProcessType(),
+		Build: domain.DeploymentBuild{
+			Git: domain.DeploymentBuildGit{
+				Branch:  d.GetBuild().GetGit().GetBranch(),
+				Commit:  d.GetBuild().GetGit().GetCommit(),
+				Message: d.GetBuild().GetGit().GetMessage(),
+			},
+			BuildTime: d.GetBuild().GetTime(),
+			BuildUser: d.GetBuild().GetUser(),
+		},
+	}
+
+	b, err := json.Marshal(deployment)
+	if err != nil {
+		return nil, fmt.Errorf("fail to build deployment : %w", err)
+	}
+	var m map[string]interface{}
+	if err = json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("fail to build deployment : %w", err)
+	}
+	return m, nil
+}
+
+func setIfNotEmpty(content map[string]interface{}, key, value string) {
+	if len(value) > 0 {
+		content[key] = value
+	}
+}
@@ -0,0 +1,244 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 29. 오후 4:00
+ */
+
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+
+	proto "github.com/fatima-go/fatima-core/builder/fatima.message.v1"
+	"github.com/fatima-go/saturn/domain"
+	message "github.com/fatima-go/saturn/proto/saturn.message.v1"
+	"github.com/fatima-go/saturn/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	v1reflectiongrpc "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/test/bufconn"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func newTestMessageConn(t *testing.T) *grpc.ClientConn {
+	listener := bufconn.Listen(1024 * 1024)
+	g := &GrpcServer{}
+	g.messageServer = newMessageServer(g.consume, g.accept)
+	g.server = grpc.NewServer()
+	message.RegisterFatimaMessageServiceServer(g.server, g)
+	registerReflection(g.server)
+	go g.server.Serve(listener)
+	t.Cleanup(g.server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("fail to connect : %s", err.Error())
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// checkResults expects results of too short message and message of other application
+func checkResults(t *testing.T, response *message.SendFatimaMessagesResponse) {
+	if response.GetAccepted() != 0 || response.GetRejected() != 2 || len(response.GetResults()) != 2 {
+		t.Fatalf("unexpected response : %v", response)
+	}
+	if response.GetResults()[0].GetError().GetGrpcResponse() != proto.ResponseError_BAD_PARAMETER ||
+		response.GetResults()[1].GetError().GetGrpcResponse() != proto.ResponseError_NOT_ACCEPTABLE {
+		t.Fatalf("results should be in the order of messages : %v", response.GetResults())
+	}
+}
+
+func TestFatimaMessageServiceBatch(t *testing.T) {
+	conn := newTestMessageConn(t)
+	requests := []*proto.SendFatimaMessageRequest{{JsonString: "x"}, {JsonString: `{"header":{}}`}}
+
+	client := message.NewFatimaMessageServiceClient(conn)
+	response, err := client.SendFatimaMessages(context.Background(), &message.SendFatimaMessagesRequest{Messages: requests})
+	if err != nil {
+		t.Fatalf("fail to call SendFatimaMessages : %s", err.Error())
+	}
+	checkResults(t, response)
+
+	stream, err := client.StreamFatimaMessages(context.Background())
+	if err != nil {
+		t.Fatalf("fail to open StreamFatimaMessages : %s", err.Error())
+	}
+	for _, r := range requests {
+		if err = stream.Send(r); err != nil {
+			t.Fatalf("fail to send : %s", err.Error())
+		}
+	}
+	response, err = stream.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("fail to receive : %s", err.Error())
+	}
+	checkResults(t, response)
+
+	// generated client of fatima-core keeps working
+	single, err := proto.NewFatimaMessageServiceClient(conn).SendFatimaMessage(context.Background(), requests[1])
+	if err != nil || single.GetError().GetGrpcResponse() != proto.ResponseError_NOT_ACCEPTABLE {
+		t.Fatalf("unexpected SendFatimaMessage response : %v, %v", single, err)
+	}
+}
+
+func TestFatimaMessageServiceReflection(t *testing.T) {
+	stream, err := v1reflectiongrpc.NewServerReflectionClient(newTestMessageConn(t)).ServerReflectionInfo(context.Background())
+	if err != nil {
+		t.Fatalf("fail to open reflection : %s", err.Error())
+	}
+	defer stream.CloseSend()
+
+	err = stream.Send(&v1reflectiongrpc.ServerReflectionRequest{
+		MessageRequest: &v1reflectiongrpc.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: "fatima.message.v1.FatimaMessageService"},
+	})
+	if err != nil {
+		t.Fatalf("fail to send reflection request : %s", err.Error())
+	}
+	response, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("fail to receive reflection response : %s", err.Error())
+	}
+
+	methods := make([]string, 0)
+	for _, b := range response.GetFileDescriptorResponse().GetFileDescriptorProto() {
+		fd := &descriptorpb.FileDescriptorProto{}
+		if err = protobuf.Unmarshal(b, fd); err != nil {
+			t.Fatalf("invalid file descriptor : %s", err.Error())
+		}
+		for _, service := range fd.GetService() {
+			if service.GetName() != "FatimaMessageService" {
+				continue
+			}
+			for _, method := range service.GetMethod() {
+				methods = append(methods, method.GetName())
+			}
+		}
+	}
+	expected := []string{"SendFatimaMessage", "SendFatimaMessages", "StreamFatimaMessages"}
+	if !reflect.DeepEqual(methods, expected) {
+		t.Fatalf("reflection should describe every rpc : %v", methods)
+	}
+}
+
+func buildTypedMessage(content *message.NotifyContent) *message.FatimaMessage {
+	return &message.FatimaMessage{
+		Header: &message.FatimaMessageHeader{ApplicationCode: 1, Logic: 20},
+		Body: &message.FatimaMessageBody{
+			EventTime:      1761700000000,
+			PackageGroup:   "svc",
+			PackageHost:    "host01",
+			PackageName:    "default",
+			PackageProcess: "api",
+			Content:        content,
+		},
+	}
+}
+
+func TestToDomainMessage(t *testing.T) {
+	header := `"header":{"application_code":1,"logic":20}`
+	body := `"event_time":1761700000000,"package_group":"svc","package_host":"host01","package_name":"default","package_process":"api"`
+	tests := []struct {
+		name    string
+		content *message.NotifyContent
+		json    string
+	}{
+		{
+			name: "major alarm",
+			content: &message.NotifyContent{Type: message.NotifyType_NOTIFY_TYPE_ALARM, AlarmLevel: message.AlarmLevel_ALARM_LEVEL_MAJOR,
+				Category: "db", Message: "connection refused", Timestamp: "2026-10-29 10:00:00", From: "api",
+				Extra: map[string]string{"region": "kr"}},
+			json: `{` + header + `,"body":{` + body + `,"message":{"type":"ALARM","alarm_level":"MAJOR","category":"db",` +
+				`"message":"connection refused","timestamp":"2026-10-29 10:00:00","from":"api","region":"kr"}}}`,
+		},
+		{
+			name: "event with params",
+			content: &message.NotifyContent{Type: message.NotifyType_NOTIFY_TYPE_EVENT, Message: "batch %s done in %s",
+				Initiator: "scheduler", Params: []string{"daily", "3m"}},
+			json: `{` + header + `,"body":{` + body + `,"message":{"type":"EVENT","message":"batch %s done in %s",` +
+				`"initiator":"scheduler","params":["daily","3m"]}}}`,
+		},
+		{
+			name: "startup with deployment",
+			content: &message.NotifyContent{Type: message.NotifyType_NOTIFY_TYPE_ALARM, Action: message.Action_ACTION_PROCESS_STARTUP,
+				AlarmLevel: message.AlarmLevel_ALARM_LEVEL_WARN, Message: "process started",
+				Deployment: &message.Deployment{Process: "api", ProcessType: "GENERAL", Build: &message.DeploymentBuild{
+					Git:  &message.DeploymentBuildGit{Branch: "main", Commit: "1a2b3c", Message: "fix login"},
+					Time: "2026-10-29 09:50:00", User: "jin"}}},
+			json: `{` + header + `,"body":{` + body + `,"message":{"type":"ALARM","action":"PROCESS_STARTUP","alarm_level":"WARN",` +
+				`"message":"process started","deployment":{"process":"api","process_type":"GENERAL","build":{` +
+				`"git":{"branch":"main","commit":"1a2b3c","message":"fix login"},"time":"2026-10-29 09:50:00","user":"jin"}}}}}`,
+		},
+		{
+			name: "minor shutdown",
+			content: &message.NotifyContent{Type: message.NotifyType_NOTIFY_TYPE_ALARM, Action: message.Action_ACTION_PROCESS_SHUTDOWN,
+				AlarmLevel: message.AlarmLevel_ALARM_LEVEL_MINOR, Message: "process stopped"},
+			json: `{` + header + `,"body":{` + body + `,"message":{"type":"ALARM","action":"PROCESS_SHUTDOWN","alarm_level":"MINOR",` +
+				`"message":"process stopped"}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		typed, err := toDomainMessage(buildTypedMessage(tt.content))
+		if err != nil {
+			t.Fatalf("%s : fail to convert : %s", tt.name, err.Error())
+		}
+
+		var expected domain.MBusMessage
+		if err = json.Unmarshal([]byte(tt.json), &expected); err != nil {
+			t.Fatalf("%s : fail to unmarshal : %s", tt.name, err.Error())
+		}
+		if !reflect.DeepEqual(typed, expected) {
+			t.Fatalf("%s : typed message differs from json\ntyped : %v\njson  : %v", tt.name, typed, expected)
+		}
+		if typed.Body.GetHashsum() != expected.Body.GetHashsum() {
+			t.Fatalf("%s : hashsum differs", tt.name)
+		}
+	}
+}
+
+func TestToDomainMessageInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content *message.NotifyContent
+	}{
+		{"no content", nil},
+		{"unspecified type", &message.NotifyContent{Message: "no type"}},
+		{"unknown alarm level", &message.NotifyContent{Type: message.NotifyType_NOTIFY_TYPE_ALARM, AlarmLevel: 9}},
+		{"unknown action", &message.NotifyContent{Type: message.NotifyType_NOTIFY_TYPE_ALARM, Action: 9}},
+		{"reserved extra alarm_id", &message.NotifyContent{Type: message.NotifyType_NOTIFY_TYPE_ALARM,
+			Extra: map[string]string{domain.MessageKeyAlarmId: "forged"}}},
+		{"reserved extra alarm_level", &message.NotifyContent{Type: message.NotifyType_NOTIFY_TYPE_ALARM,
+			Extra: map[string]string{domain.MessageKeyAlarmLevel: "MAJOR"}}},
+	}
+
+	for _, tt := range tests {
+		if _, err := toDomainMessage(buildTypedMessage(tt.content)); !errors.Is(err, service.ErrInvalidParameter) {
+			t.Fatalf("%s : expected invalid parameter : %v", tt.name, err)
+		}
+	}
+}
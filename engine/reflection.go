@@ -0,0 +1,66 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 19. 오후 10:20
+ */
+
+package engine
+
+import (
+	log "github.com/fatima-go/fatima-log"
+	message "github.com/fatima-go/saturn/proto/saturn.message.v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	v1reflectiongrpc "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	v1alphareflectiongrpc "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// registerReflection registers server reflection (v1 and v1alpha) which describes FatimaMessageService
+// with fatima.message.v1.proto of saturn instead of the one of fatima-core
+func registerReflection(server *grpc.Server) {
+	files, err := message.NewFiles()
+	if err != nil {
+		log.Warn("fail to load saturn descriptors. use descriptors of fatima-core : %s", err.Error())
+		reflection.Register(server)
+		return
+	}
+
+	options := reflection.ServerOptions{Services: server, DescriptorResolver: descriptorResolver{files: files}}
+	v1alphareflectiongrpc.RegisterServerReflectionServer(server, reflection.NewServer(options))
+	v1reflectiongrpc.RegisterServerReflectionServer(server, reflection.NewServerV1(options))
+}
+
+// descriptorResolver finds descriptors of saturn proto files first and then protoregistry.GlobalFiles
+type descriptorResolver struct {
+	files *protoregistry.Files
+}
+
+func (d descriptorResolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if fd, err := d.files.FindFileByPath(path); err == nil {
+		return fd, nil
+	}
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+func (d descriptorResolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if desc, err := d.files.FindDescriptorByName(name); err == nil {
+		return desc, nil
+	}
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
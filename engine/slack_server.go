@@ -0,0 +1,104 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 24. 오후 2:30
+ */
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/fatima-go/fatima-core"
+	"github.com/fatima-go/fatima-log"
+	"github.com/fatima-go/saturn/notifier/slack"
+	"github.com/fatima-go/saturn/service"
+)
+
+const (
+	slackInteractionPath = "/slack/interactions"
+)
+
+func NewSlackInteractionServer(fatimaRuntime fatima.FatimaRuntime, applicationExecutor service.ApplicationExecutor) *SlackInteractionServer {
+	server := new(SlackInteractionServer)
+	server.fatimaRuntime = fatimaRuntime
+	server.applicationExecutor = applicationExecutor
+	return server
+}
+
+// SlackInteractionServer receives slack button actions (acknowledge, silence, snooze) over http
+// it is enabled only when slack.interaction.listen is specified
+type SlackInteractionServer struct {
+	fatimaRuntime       fatima.FatimaRuntime
+	applicationExecutor service.ApplicationExecutor
+	server              *http.Server
+}
+
+func (s *SlackInteractionServer) Initialize() bool {
+	log.Info("SlackInteractionServer Initialize()")
+
+	address, err := s.fatimaRuntime.GetConfig().GetString(slack.PropertyInteractionListen)
+	if err != nil || len(address) == 0 {
+		log.Info("slack interaction is disabled")
+		return true
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		log.Warn("failed to listen: %v", err)
+		return false
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(slackInteractionPath, slack.NewInteractionHandler(s.fatimaRuntime, s.applicationExecutor))
+	s.server = &http.Server{Handler: mux, ReadHeaderTimeout: time.Second * 10}
+
+	log.Info("slack interaction server start. address=%v, path=%s", address, slackInteractionPath)
+	go func() {
+		err := s.server.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Warn("slack interaction serving error : %s", err.Error())
+		}
+	}()
+	return true
+}
+
+func (s *SlackInteractionServer) Bootup() {
+	log.Info("SlackInteractionServer Bootup()")
+}
+
+func (s *SlackInteractionServer) Goaway() {
+}
+
+func (s *SlackInteractionServer) Shutdown() {
+	log.Info("SlackInteractionServer Shutdown()")
+	if s.server == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	s.server.Shutdown(ctx)
+}
+
+func (s *SlackInteractionServer) GetType() fatima.FatimaComponentType {
+	return fatima.COMP_READER
+}
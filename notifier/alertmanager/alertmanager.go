@@ -0,0 +1,403 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 19. 오후 4:50
+ */
+
+package alertmanager
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatima-go/fatima-core"
+	log "github.com/fatima-go/fatima-log"
+	"github.com/fatima-go/saturn/domain"
+)
+
+const (
+	fileApiAlertmanager      = "api.alertmanager"
+	applicationJsonUtf8Value = "application/json;charset=UTF-8"
+	pathPostAlerts           = "/api/v2/alerts"
+	defaultAlertName         = "FatimaAlarm"
+	defaultResendInterval    = time.Minute
+	defaultActivePeriod      = time.Hour
+	queueSize                = 256
+)
+
+func NewAlertmanagerNotification(fatimaRuntime fatima.FatimaRuntime) *AlertmanagerNotification {
+	am := AlertmanagerNotification{}
+	am.fatimaRuntime = fatimaRuntime
+	am.mutex = &sync.Mutex{}
+	am.client = &http.Client{Timeout: time.Second * 10}
+	am.alerts = make(map[string]*activeAlert)
+	am.queue = make(chan postJob, queueSize)
+
+	go am.run()
+	go am.resend()
+	return &am
+}
+
+// AlertmanagerNotification forwards alarm to prometheus alertmanager
+type AlertmanagerNotification struct {
+	fatimaRuntime   fatima.FatimaRuntime
+	lastLoadingTime time.Time
+	config          AlertmanagerConfig
+	mutex           *sync.Mutex
+	client          *http.Client
+	alerts          map[string]*activeAlert // label fingerprint -> firing alert
+	lastResendTime  time.Time
+	queue           chan postJob
+}
+
+// postJob is alerts to post. alerts are posted one by one by single worker
+type postJob struct {
+	config AlertmanagerConfig
+	alerts []Alert
+	done   func(err error)
+}
+
+type AlertmanagerConfig struct {
+	Active       bool   `json:"active"`
+	Url          string `json:"url"`
+	Resend       string `json:"resend"`
+	ActivePeriod string `json:"active_period"`
+	GeneratorUrl string `json:"generator_url,omitempty"`
+}
+
+type Alert struct {
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       *time.Time        `json:"endsAt,omitempty"`
+	GeneratorURL string            `json:"generatorURL,omitempty"`
+}
+
+type activeAlert struct {
+	alert    Alert
+	source   string
+	lastSeen time.Time
+}
+
+func (a *AlertmanagerNotification) SendNotify(mbus domain.MBusMessageBody) {
+	a.SendNotifyWithAck(mbus, nil)
+}
+
+// SendNotifyWithAck calls done with the result of posting alert
+func (a *AlertmanagerNotification) SendNotifyWithAck(mbus domain.MBusMessageBody, done func(err error)) {
+	if !mbus.IsAlarm() {
+		finish(done, nil)
+		return
+	}
+
+	config, ok := a.getConfig()
+	if !ok {
+		finish(done, nil)
+		return
+	}
+
+	if mbus.IsProcessStartup() {
+		a.resolve(config, mbus.GetSourceKey())
+		finish(done, nil)
+		return
+	}
+
+	alert := buildAlert(mbus, config)
+	key := fingerprint(alert.Labels)
+
+	a.mutex.Lock()
+	active, ok := a.alerts[key]
+	if ok {
+		// keep startsAt of first occurrence
+		alert.StartsAt = active.alert.StartsAt
+	}
+	a.alerts[key] = &activeAlert{alert: alert, source: mbus.GetSourceKey(), lastSeen: time.Now()}
+	a.mutex.Unlock()
+
+	a.enqueue(postJob{config: config, alerts: []Alert{alert}, done: done})
+}
+
+// SendAlarmState ends alert in alertmanager when the alarm is resolved or silenced in saturn
+func (a *AlertmanagerNotification) SendAlarmState(alarm domain.Alarm) {
+	if alarm.IsOpen() {
+		return
+	}
+
+	config, ok := a.getConfig()
+	if !ok {
+		return
+	}
+
+	key := fingerprint(buildAlert(alarm.Message, config).Labels)
+	a.mutex.Lock()
+	active, ok := a.alerts[key]
+	if ok {
+		delete(a.alerts, key)
+	}
+	a.mutex.Unlock()
+
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	alert := active.alert
+	alert.EndsAt = &now
+	log.Info("alarm %s %s. resolve alertmanager alert", alarm.Id, alarm.State)
+	a.enqueue(postJob{config: config, alerts: []Alert{alert}})
+}
+
+func (a *AlertmanagerNotification) resolve(config AlertmanagerConfig, source string) {
+	now := time.Now()
+	resolved := make([]Alert, 0)
+
+	a.mutex.Lock()
+	for k, v := range a.alerts {
+		if v.source != source {
+			continue
+		}
+		alert := v.alert
+		alert.EndsAt = &now
+		resolved = append(resolved, alert)
+		delete(a.alerts, k)
+	}
+	a.mutex.Unlock()
+
+	if len(resolved) == 0 {
+		return
+	}
+
+	log.Info("resolve %d alertmanager alerts for %s", len(resolved), source)
+	a.enqueue(postJob{config: config, alerts: resolved})
+}
+
+// resend re-posts firing alerts so that alertmanager keeps them active
+func (a *AlertmanagerNotification) resend() {
+	ticker := time.NewTicker(time.Second * 10)
+	for range ticker.C {
+		config, ok := a.getConfig()
+		if !ok {
+			continue
+		}
+
+		if time.Since(a.lastResendTime) < config.getResend() {
+			continue
+		}
+		a.lastResendTime = time.Now()
+		a.resendAlerts(config, a.lastResendTime)
+	}
+}
+
+// resendAlerts posts firing alerts again and ends alerts not seen during active period
+func (a *AlertmanagerNotification) resendAlerts(config AlertmanagerConfig, now time.Time) {
+	firing := make([]Alert, 0)
+	expired := make([]Alert, 0)
+	a.mutex.Lock()
+	for k, v := range a.alerts {
+		if now.Sub(v.lastSeen) > config.getActivePeriod() {
+			alert := v.alert
+			alert.EndsAt = &now
+			expired = append(expired, alert)
+			delete(a.alerts, k)
+			continue
+		}
+		firing = append(firing, v.alert)
+	}
+	a.mutex.Unlock()
+
+	if len(expired) > 0 {
+		log.Info("expire %d alertmanager alerts", len(expired))
+		firing = append(firing, expired...)
+	}
+
+	if len(firing) > 0 {
+		a.enqueue(postJob{config: config, alerts: firing})
+	}
+}
+
+// IsQueueFull returns true when new alerts would be dropped
+func (a *AlertmanagerNotification) IsQueueFull() bool {
+	return len(a.queue) >= cap(a.queue)
+}
+
+func (a *AlertmanagerNotification) enqueue(job postJob) {
+	select {
+	case a.queue <- job:
+	default:
+		log.Warn("alertmanager queue is full. drop %d alerts", len(job.alerts))
+		finish(job.done, fmt.Errorf("alertmanager %w", domain.ErrQueueFull))
+	}
+}
+
+func (a *AlertmanagerNotification) run() {
+	for job := range a.queue {
+		finish(job.done, a.post(job.config, job.alerts))
+	}
+}
+
+func (a *AlertmanagerNotification) post(config AlertmanagerConfig, alerts []Alert) error {
+	b, err := json.Marshal(alerts)
+	if err != nil {
+		log.Warn("fail to build json : %s", err.Error())
+		return err
+	}
+
+	resp, err := a.client.Post(config.Url+pathPostAlerts, applicationJsonUtf8Value, bytes.NewBuffer(b))
+	if err != nil {
+		log.Warn("fail to send alertmanager alerts : %s", err.Error())
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		log.Debug("successfully send %d alerts to alertmanager", len(alerts))
+		return nil
+	}
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	log.Info("alertmanager response : %s %s", resp.Status, string(msg))
+	return fmt.Errorf("alertmanager response : %s", resp.Status)
+}
+
+// finish calls done callback if exists
+func finish(done func(err error), err error) {
+	if done != nil {
+		done(err)
+	}
+}
+
+func (a *AlertmanagerNotification) getConfig() (AlertmanagerConfig, bool) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	deadline := time.Now().Add(-time.Second * 10)
+	if a.lastLoadingTime.Before(deadline) {
+		a.loading()
+	}
+	if !a.config.Active || len(a.config.Url) < 6 {
+		return a.config, false
+	}
+	return a.config, true
+}
+
+func (a *AlertmanagerNotification) loading() {
+	a.lastLoadingTime = time.Now()
+	if a.fatimaRuntime == nil {
+		log.Warn("fatimaRuntime is nil")
+		return
+	}
+
+	configFile := filepath.Join(a.fatimaRuntime.GetEnv().GetFolderGuide().GetDataFolder(), fileApiAlertmanager)
+	dataBytes, err := os.ReadFile(configFile)
+	if err != nil {
+		return
+	}
+
+	var config AlertmanagerConfig
+	err = json.Unmarshal(dataBytes, &config)
+	if err != nil {
+		log.Warn("fail to unmarshal %s : %s", fileApiAlertmanager, err.Error())
+		return
+	}
+
+	config.Url = strings.TrimRight(config.Url, "/")
+	a.config = config
+	log.Debug("alertmanager config loaded : url=%s, active=%v", config.Url, config.Active)
+}
+
+func (c AlertmanagerConfig) getResend() time.Duration {
+	return parseDuration(c.Resend, defaultResendInterval)
+}
+
+func (c AlertmanagerConfig) getActivePeriod() time.Duration {
+	return parseDuration(c.ActivePeriod, defaultActivePeriod)
+}
+
+func parseDuration(value string, defaultValue time.Duration) time.Duration {
+	if len(value) == 0 {
+		return defaultValue
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Warn("invalid duration %s : %s", value, err.Error())
+		return defaultValue
+	}
+	return d
+}
+
+func buildAlert(mbus domain.MBusMessageBody, config AlertmanagerConfig) Alert {
+	alert := Alert{}
+	alert.Labels = make(map[string]string)
+	alert.Labels["alertname"] = buildAlertName(mbus)
+	alert.Labels["severity"] = strings.ToLower(mbus.GetAlarmLevel())
+	alert.Labels["group"] = mbus.PackageGroup
+	alert.Labels["host"] = mbus.PackageHost
+	alert.Labels["process"] = mbus.PackageProcess
+	if len(mbus.PackageProfile) > 0 {
+		alert.Labels["profile"] = mbus.PackageProfile
+	}
+
+	text := strings.TrimSpace(mbus.GetText())
+	summary := text
+	if idx := strings.IndexByte(summary, '\n'); idx > 0 {
+		summary = summary[:idx]
+	}
+	alert.Annotations = map[string]string{"summary": summary, "description": text}
+
+	alert.StartsAt = time.UnixMilli(int64(mbus.EventTime))
+	if mbus.EventTime == 0 {
+		alert.StartsAt = time.Now()
+	}
+
+	if len(config.GeneratorUrl) > 0 {
+		alert.GeneratorURL = config.GeneratorUrl
+	}
+	return alert
+}
+
+func buildAlertName(mbus domain.MBusMessageBody) string {
+	if action := mbus.GetAction(); len(action) > 0 {
+		return action
+	}
+	if category := mbus.GetCategory(); len(category) > 0 {
+		return category
+	}
+	return defaultAlertName
+}
+
+func fingerprint(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buff bytes.Buffer
+	for _, k := range keys {
+		buff.WriteString(fmt.Sprintf("%s=%s,", k, labels[k]))
+	}
+	return buff.String()
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 19. 오후 9:30
+ */
+
+package alertmanager
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fatima-go/saturn/domain"
+)
+
+// newAlertmanagerStandIn returns local stand-in of alertmanager which passes posted alerts to the channel
+func newAlertmanagerStandIn(t *testing.T, posted chan []Alert, failing *atomic.Bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != pathPostAlerts {
+			t.Errorf("unexpected request : %s %s", r.Method, r.URL.Path)
+		}
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		var alerts []Alert
+		if err := json.NewDecoder(r.Body).Decode(&alerts); err != nil {
+			t.Errorf("invalid alerts : %s", err.Error())
+		}
+		posted <- alerts
+	}))
+}
+
+func buildAlarmMessage(process, action string, eventTime int) domain.MBusMessageBody {
+	return domain.MBusMessageBody{
+		EventTime:      eventTime,
+		PackageGroup:   "test_group",
+		PackageHost:    "test_host",
+		PackageName:    "default",
+		PackageProcess: process,
+		Message: map[string]interface{}{
+			domain.MessageKeyType:       domain.NotifyAlarm,
+			domain.MessageKeyAlarmLevel: domain.AlarmLevelMajor,
+			domain.MessageKeyAction:     action,
+			domain.MessageKeyMessage:    "fail to connect database\ncaused by timeout",
+		},
+	}
+}
+
+func waitPosted(t *testing.T, posted chan []Alert) []Alert {
+	select {
+	case alerts := <-posted:
+		return alerts
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alerts are not posted")
+	}
+	return nil
+}
+
+func sendAlarm(t *testing.T, am *AlertmanagerNotification, mbus domain.MBusMessageBody) error {
+	result := make(chan error, 1)
+	am.SendNotifyWithAck(mbus, func(err error) { result <- err })
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alertmanager ack timeout")
+	}
+	return nil
+}
+
+func TestAlertmanagerLifecycle(t *testing.T) {
+	posted := make(chan []Alert, 8)
+	var failing atomic.Bool
+	server := newAlertmanagerStandIn(t, posted, &failing)
+	defer server.Close()
+
+	config := AlertmanagerConfig{Active: true, Url: server.URL, ActivePeriod: "1h"}
+	am := &AlertmanagerNotification{
+		lastLoadingTime: time.Now(),
+		config:          config,
+		mutex:           &sync.Mutex{},
+		client:          server.Client(),
+		alerts:          make(map[string]*activeAlert),
+		queue:           make(chan postJob, queueSize),
+	}
+	go am.run()
+
+	// first alarm
+	if err := sendAlarm(t, am, buildAlarmMessage("test", "", 1700000000000)); err != nil {
+		t.Fatalf("fail to post alert : %s", err.Error())
+	}
+	alerts := waitPosted(t, posted)
+	if len(alerts) != 1 || alerts[0].EndsAt != nil || alerts[0].Labels["alertname"] != defaultAlertName || alerts[0].Labels["severity"] != "major" {
+		t.Fatalf("unexpected alert : %v", alerts)
+	}
+	if alerts[0].Annotations["summary"] != "fail to connect database" {
+		t.Fatalf("summary should be first line : %s", alerts[0].Annotations["summary"])
+	}
+	startsAt := alerts[0].StartsAt
+
+	// same fingerprint keeps startsAt of first occurrence
+	sendAlarm(t, am, buildAlarmMessage("test", "", 1700000060000))
+	if alerts = waitPosted(t, posted); !alerts[0].StartsAt.Equal(startsAt) || len(am.alerts) != 1 {
+		t.Fatalf("same alarm should keep startsAt : %s, alerts=%d", alerts[0].StartsAt, len(am.alerts))
+	}
+
+	// resend keeps firing alerts and expires old ones
+	sendAlarm(t, am, buildAlarmMessage("other", "", 1700000000000))
+	waitPosted(t, posted)
+	am.mutex.Lock()
+	for _, v := range am.alerts {
+		if v.source == "test_group:test_host:other" {
+			v.lastSeen = time.Now().Add(-time.Hour * 2)
+		}
+	}
+	am.mutex.Unlock()
+	am.resendAlerts(config, time.Now())
+	alerts = waitPosted(t, posted)
+	if len(alerts) != 2 || len(am.alerts) != 1 {
+		t.Fatalf("firing and expired alerts should be posted : %v, alerts=%d", alerts, len(am.alerts))
+	}
+	for _, alert := range alerts {
+		if (alert.Labels["process"] == "other") != (alert.EndsAt != nil) {
+			t.Fatalf("only expired alert should have endsAt : %v", alert)
+		}
+	}
+
+	// startup resolves alerts of the process
+	if err := sendAlarm(t, am, buildAlarmMessage("test", domain.ActionProcessStartup, 1700000120000)); err != nil {
+		t.Fatalf("fail to resolve alert : %s", err.Error())
+	}
+	if alerts = waitPosted(t, posted); len(alerts) != 1 || alerts[0].EndsAt == nil || len(am.alerts) != 0 {
+		t.Fatalf("startup should end alert : %v", alerts)
+	}
+
+	// failure is acknowledged
+	failing.Store(true)
+	if err := sendAlarm(t, am, buildAlarmMessage("test", "", 1700000180000)); err == nil {
+		t.Fatalf("failure should be acknowledged")
+	}
+}
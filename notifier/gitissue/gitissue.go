@@ -0,0 +1,375 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 19. 오후 3:40
+ */
+
+package gitissue
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatima-go/fatima-core"
+	log "github.com/fatima-go/fatima-log"
+	"github.com/fatima-go/saturn/domain"
+)
+
+const (
+	fileApiGitIssue          = "api.gitissue"
+	fileMappingGitIssue      = "mapping.gitissue"
+	applicationJsonUtf8Value = "application/json;charset=UTF-8"
+	providerGithub           = "github"
+	providerGitlab           = "gitlab"
+	defaultGithubUrl         = "https://api.github.com"
+	defaultGitlabUrl         = "https://gitlab.com/api/v4"
+	defaultWindow            = time.Minute * 30
+	queueSize                = 256
+)
+
+func NewGitIssueNotification(fatimaRuntime fatima.FatimaRuntime) *GitIssueNotification {
+	notification := GitIssueNotification{}
+	notification.fatimaRuntime = fatimaRuntime
+	notification.mutex = &sync.Mutex{}
+	notification.client = &http.Client{Timeout: time.Second * 10}
+	notification.mapping = make(map[string]RepositoryMapping)
+	notification.deploys = make(map[string]deployRecord)
+	notification.queue = make(chan gitIssueJob, queueSize)
+
+	go notification.run()
+	return &notification
+}
+
+// GitIssueNotification opens github/gitlab issue when process breaks soon after deployment
+type GitIssueNotification struct {
+	fatimaRuntime   fatima.FatimaRuntime
+	lastLoadingTime time.Time
+	config          GitIssueConfig
+	mapping         map[string]RepositoryMapping // process -> repository
+	mutex           *sync.Mutex
+	client          *http.Client
+	deploys         map[string]deployRecord // group:host:process -> last deployment
+	queue           chan gitIssueJob
+}
+
+type gitIssueJob struct {
+	mbus domain.MBusMessageBody
+	done func(err error)
+}
+
+type GitIssueConfig struct {
+	Active    bool                      `json:"active"`
+	Window    string                    `json:"window"`
+	Labels    []string                  `json:"labels,omitempty"`
+	Providers map[string]ProviderConfig `json:"providers"`
+}
+
+type ProviderConfig struct {
+	Url   string `json:"url"`
+	Token string `json:"token"`
+}
+
+type RepositoryMapping struct {
+	Provider   string `json:"provider"`
+	Repository string `json:"repository"`
+}
+
+type deployRecord struct {
+	startupTime time.Time
+	build       domain.DeploymentBuild
+	reported    bool
+}
+
+func (g *GitIssueNotification) SendNotify(mbus domain.MBusMessageBody) {
+	g.SendNotifyWithAck(mbus, nil)
+}
+
+// SendNotifyWithAck calls done after the message is reported or found to need no issue
+func (g *GitIssueNotification) SendNotifyWithAck(mbus domain.MBusMessageBody, done func(err error)) {
+	if !mbus.IsAlarm() {
+		finish(done, nil)
+		return
+	}
+
+	if !mbus.IsProcessStartupOrShutdown() && !mbus.IsMajorAlarm() {
+		finish(done, nil)
+		return
+	}
+
+	select {
+	case g.queue <- gitIssueJob{mbus: mbus, done: done}:
+	default:
+		log.Warn("gitissue queue is full. drop message : %s", mbus.GetSourceKey())
+		finish(done, fmt.Errorf("gitissue %w", domain.ErrQueueFull))
+	}
+}
+
+// IsQueueFull returns true when new message would be dropped
+func (g *GitIssueNotification) IsQueueFull() bool {
+	return len(g.queue) >= cap(g.queue)
+}
+
+func (g *GitIssueNotification) run() {
+	for job := range g.queue {
+		if job.mbus.IsProcessStartup() {
+			g.recordDeployment(job.mbus)
+			finish(job.done, nil)
+			continue
+		}
+
+		config, ok := g.getConfig()
+		if !ok {
+			finish(job.done, nil)
+			continue
+		}
+		finish(job.done, g.report(config, job.mbus))
+	}
+}
+
+func (g *GitIssueNotification) recordDeployment(mbus domain.MBusMessageBody) {
+	dep := mbus.GetDeployment()
+	if !dep.Valid || !dep.HasBuildInfo() || !dep.Build.HasGit() {
+		delete(g.deploys, mbus.GetSourceKey())
+		return
+	}
+
+	g.deploys[mbus.GetSourceKey()] = deployRecord{startupTime: time.Now(), build: dep.Build}
+}
+
+// report opens issue when process breaks within the window after deployment
+func (g *GitIssueNotification) report(config GitIssueConfig, mbus domain.MBusMessageBody) error {
+	record, ok := g.deploys[mbus.GetSourceKey()]
+	if !ok || record.reported {
+		return nil
+	}
+
+	if time.Since(record.startupTime) > config.getWindow() {
+		delete(g.deploys, mbus.GetSourceKey())
+		return nil
+	}
+
+	repository, ok := g.getRepository(mbus.PackageProcess)
+	if !ok {
+		log.Debug("no repository mapping for process %s", mbus.PackageProcess)
+		return nil
+	}
+
+	provider, ok := config.Providers[repository.Provider]
+	if !ok {
+		log.Warn("gitissue provider %s is not configured", repository.Provider)
+		return nil
+	}
+
+	title := buildTitle(mbus, record)
+	body := buildBody(mbus, record)
+
+	var err error
+	switch repository.Provider {
+	case providerGithub:
+		err = g.openGithubIssue(provider, repository.Repository, title, body, config.Labels)
+	case providerGitlab:
+		err = g.openGitlabIssue(provider, repository.Repository, title, body, config.Labels)
+	default:
+		err = fmt.Errorf("unsupported provider %s", repository.Provider)
+	}
+
+	if err != nil {
+		log.Warn("fail to open %s issue for %s : %s", repository.Provider, repository.Repository, err.Error())
+		return err
+	}
+
+	log.Info("%s issue opened for %s (%s)", repository.Provider, repository.Repository, record.build.Git)
+	record.reported = true
+	g.deploys[mbus.GetSourceKey()] = record
+	return nil
+}
+
+func (g *GitIssueNotification) openGithubIssue(provider ProviderConfig, repository, title, body string, labels []string) error {
+	baseUrl := provider.Url
+	if len(baseUrl) == 0 {
+		baseUrl = defaultGithubUrl
+	}
+
+	issue := map[string]interface{}{"title": title, "body": body}
+	if len(labels) > 0 {
+		issue["labels"] = labels
+	}
+
+	header := http.Header{}
+	header.Set("Accept", "application/vnd.github+json")
+	header.Set("Authorization", "Bearer "+provider.Token)
+	return g.post(fmt.Sprintf("%s/repos/%s/issues", strings.TrimRight(baseUrl, "/"), repository), header, issue)
+}
+
+func (g *GitIssueNotification) openGitlabIssue(provider ProviderConfig, repository, title, body string, labels []string) error {
+	baseUrl := provider.Url
+	if len(baseUrl) == 0 {
+		baseUrl = defaultGitlabUrl
+	}
+
+	issue := map[string]interface{}{"title": title, "description": body}
+	if len(labels) > 0 {
+		issue["labels"] = strings.Join(labels, ",")
+	}
+
+	header := http.Header{}
+	header.Set("PRIVATE-TOKEN", provider.Token)
+	return g.post(fmt.Sprintf("%s/projects/%s/issues", strings.TrimRight(baseUrl, "/"), url.PathEscape(repository)), header, issue)
+}
+
+func (g *GitIssueNotification) post(targetUrl string, header http.Header, issue map[string]interface{}) error {
+	b, err := json.Marshal(issue)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, targetUrl, bytes.NewBuffer(b))
+	if err != nil {
+		return err
+	}
+	req.Header = header
+	req.Header.Set("Content-Type", applicationJsonUtf8Value)
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("response %s : %s", resp.Status, string(msg))
+	}
+	return nil
+}
+
+func (g *GitIssueNotification) getConfig() (GitIssueConfig, bool) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	deadline := time.Now().Add(-time.Second * 10)
+	if g.lastLoadingTime.Before(deadline) {
+		g.loading()
+	}
+	return g.config, g.config.Active
+}
+
+func (g *GitIssueNotification) getRepository(process string) (RepositoryMapping, bool) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	repository, ok := g.mapping[process]
+	return repository, ok
+}
+
+func (g *GitIssueNotification) loading() {
+	g.lastLoadingTime = time.Now()
+	if g.fatimaRuntime == nil {
+		log.Warn("fatimaRuntime is nil")
+		return
+	}
+
+	dataFolder := g.fatimaRuntime.GetEnv().GetFolderGuide().GetDataFolder()
+	dataBytes, err := os.ReadFile(filepath.Join(dataFolder, fileApiGitIssue))
+	if err != nil {
+		return
+	}
+
+	var config GitIssueConfig
+	err = json.Unmarshal(dataBytes, &config)
+	if err != nil {
+		log.Warn("fail to unmarshal %s : %s", fileApiGitIssue, err.Error())
+		return
+	}
+	g.config = config
+
+	dataBytes, err = os.ReadFile(filepath.Join(dataFolder, fileMappingGitIssue))
+	if err != nil {
+		log.Warn("fail to read %s : %s", fileMappingGitIssue, err.Error())
+		return
+	}
+
+	var mapping map[string]RepositoryMapping
+	err = json.Unmarshal(dataBytes, &mapping)
+	if err != nil {
+		log.Warn("fail to unmarshal %s : %s", fileMappingGitIssue, err.Error())
+		return
+	}
+	g.mapping = mapping
+
+	log.Debug("gitissue config loaded : active=%v, mapping[%d]", config.Active, len(mapping))
+}
+
+func (c GitIssueConfig) getWindow() time.Duration {
+	if len(c.Window) == 0 {
+		return defaultWindow
+	}
+
+	d, err := time.ParseDuration(c.Window)
+	if err != nil {
+		log.Warn("invalid gitissue window %s : %s", c.Window, err.Error())
+		return defaultWindow
+	}
+	return d
+}
+
+func buildTitle(mbus domain.MBusMessageBody, record deployRecord) string {
+	commit := record.build.Git.Commit
+	if len(commit) > 8 {
+		commit = commit[:8]
+	}
+
+	what := "alarm"
+	if mbus.IsProcessShutdown() {
+		what = "shutdown"
+	}
+	return fmt.Sprintf("%s %s after deploy %s (%s)", mbus.PackageProcess, what, commit, record.build.Git.Branch)
+}
+
+func buildBody(mbus domain.MBusMessageBody, record deployRecord) string {
+	var buff bytes.Buffer
+	buff.WriteString(fmt.Sprintf("`%s` reported %s %s after deployment.\n\n",
+		mbus.GetSourceKey(),
+		mbus.GetAlarmLevel(),
+		time.Since(record.startupTime).Truncate(time.Second)))
+	buff.WriteString("```\n")
+	buff.WriteString(mbus.GetText())
+	buff.WriteString("\n```\n\n")
+	buff.WriteString(fmt.Sprintf("- profile : %s\n", mbus.PackageProfile))
+	buff.WriteString(fmt.Sprintf("- commit : %s\n", record.build.Git.Commit))
+	buff.WriteString(fmt.Sprintf("- branch : %s\n", record.build.Git.Branch))
+	buff.WriteString(fmt.Sprintf("- build user : %s\n", record.build.BuildUser))
+	buff.WriteString(fmt.Sprintf("- build time : %s\n", record.build.BuildTime))
+	if len(record.build.Git.Message) > 0 {
+		buff.WriteString(fmt.Sprintf("- commit message : %s\n", strings.TrimSpace(domain.GetTrimmedMessage(record.build.Git.Message))))
+	}
+	return buff.String()
+}
+
+// finish calls done callback if exists
+func finish(done func(err error), err error) {
+	if done != nil {
+		done(err)
+	}
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 19. 오후 9:05
+ */
+
+package gitissue
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fatima-go/saturn/domain"
+)
+
+type gitApiCall struct {
+	path    string
+	header  http.Header
+	payload map[string]interface{}
+}
+
+// newGitApiStandIn returns local stand-in of github/gitlab issue api which records calls
+func newGitApiStandIn(calls *[]gitApiCall, mutex *sync.Mutex) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := gitApiCall{path: r.URL.EscapedPath(), header: r.Header}
+		json.NewDecoder(r.Body).Decode(&call.payload)
+		mutex.Lock()
+		*calls = append(*calls, call)
+		mutex.Unlock()
+
+		if strings.Contains(call.path, "broken") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+}
+
+func buildGitMessage(process, action string, deployed bool) domain.MBusMessageBody {
+	mbus := domain.MBusMessageBody{
+		EventTime:      1700000000000,
+		PackageGroup:   "test_group",
+		PackageHost:    "test_host",
+		PackageName:    "default",
+		PackageProcess: process,
+		Message: map[string]interface{}{
+			domain.MessageKeyType:       domain.NotifyAlarm,
+			domain.MessageKeyAlarmLevel: domain.AlarmLevelMajor,
+			domain.MessageKeyAction:     action,
+			domain.MessageKeyMessage:    "process " + process + " " + action,
+		},
+	}
+	if deployed {
+		mbus.Message[domain.MessageKeyDeployment] = map[string]interface{}{
+			"process": process,
+			"build": map[string]interface{}{
+				"time": "2026-10-19 20:00:00",
+				"user": "jin",
+				"git":  map[string]interface{}{"branch": "main", "commit": "0123abcd4567ef"},
+			},
+		}
+	}
+	return mbus
+}
+
+func sendGitIssue(t *testing.T, g *GitIssueNotification, mbus domain.MBusMessageBody) error {
+	result := make(chan error, 1)
+	g.SendNotifyWithAck(mbus, func(err error) { result <- err })
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(time.Second * 5):
+		t.Fatalf("gitissue ack timeout")
+	}
+	return nil
+}
+
+func TestGitIssueAfterDeployment(t *testing.T) {
+	calls := make([]gitApiCall, 0)
+	var mutex sync.Mutex
+	server := newGitApiStandIn(&calls, &mutex)
+	defer server.Close()
+
+	g := NewGitIssueNotification(nil)
+	g.mutex.Lock()
+	g.lastLoadingTime = time.Now()
+	g.config = GitIssueConfig{
+		Active: true,
+		Window: "1m",
+		Labels: []string{"incident", "deploy"},
+		Providers: map[string]ProviderConfig{
+			providerGithub: {Url: server.URL, Token: "ghp-test"},
+			providerGitlab: {Url: server.URL + "/", Token: "glpat-test"},
+		},
+	}
+	g.mapping = map[string]RepositoryMapping{
+		"test":   {Provider: providerGithub, Repository: "fatima-go/saturn"},
+		"worker": {Provider: providerGitlab, Repository: "fatima/worker"},
+		"broken": {Provider: providerGithub, Repository: "fatima-go/broken"},
+	}
+	g.mutex.Unlock()
+
+	// github issue for shutdown soon after deployment
+	sendGitIssue(t, g, buildGitMessage("test", domain.ActionProcessStartup, true))
+	if err := sendGitIssue(t, g, buildGitMessage("test", domain.ActionProcessShutdown, false)); err != nil {
+		t.Fatalf("fail to open github issue : %s", err.Error())
+	}
+	if len(calls) != 1 || calls[0].path != "/repos/fatima-go/saturn/issues" || calls[0].header.Get("Authorization") != "Bearer ghp-test" {
+		t.Fatalf("github issue should be opened : %v", calls)
+	}
+	if calls[0].payload["title"] != "test shutdown after deploy 0123abcd (main)" {
+		t.Fatalf("unexpected title : %v", calls[0].payload["title"])
+	}
+
+	// only one issue for a deployment
+	sendGitIssue(t, g, buildGitMessage("test", "", false))
+	if len(calls) != 1 {
+		t.Fatalf("deployment should be reported once : %v", calls)
+	}
+
+	// out of window
+	sendGitIssue(t, g, buildGitMessage("worker", domain.ActionProcessStartup, true))
+	record := g.deploys["test_group:test_host:worker"]
+	record.startupTime = time.Now().Add(-time.Minute * 2)
+	g.deploys["test_group:test_host:worker"] = record
+	sendGitIssue(t, g, buildGitMessage("worker", "", false))
+	if len(calls) != 1 || len(g.deploys) != 1 {
+		t.Fatalf("alarm out of window should not open issue : %v, deploys=%d", calls, len(g.deploys))
+	}
+
+	// gitlab issue
+	sendGitIssue(t, g, buildGitMessage("worker", domain.ActionProcessStartup, true))
+	if err := sendGitIssue(t, g, buildGitMessage("worker", "", false)); err != nil {
+		t.Fatalf("fail to open gitlab issue : %s", err.Error())
+	}
+	if len(calls) != 2 || calls[1].path != "/projects/fatima%2Fworker/issues" || calls[1].header.Get("PRIVATE-TOKEN") != "glpat-test" {
+		t.Fatalf("gitlab issue should be opened : %v", calls)
+	}
+	if calls[1].payload["labels"] != "incident,deploy" || calls[1].payload["description"] == nil {
+		t.Fatalf("unexpected gitlab issue : %v", calls[1].payload)
+	}
+
+	// failure is acknowledged
+	sendGitIssue(t, g, buildGitMessage("broken", domain.ActionProcessStartup, true))
+	if err := sendGitIssue(t, g, buildGitMessage("broken", "", false)); err == nil {
+		t.Fatalf("failure should be acknowledged")
+	}
+}
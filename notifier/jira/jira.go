@@ -0,0 +1,454 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 19. 오후 2:10
+ */
+
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatima-go/fatima-core"
+	log "github.com/fatima-go/fatima-log"
+	"github.com/fatima-go/saturn/domain"
+)
+
+const (
+	fileApiJira              = "api.jira"
+	fileIssueJira            = "issue.jira"
+	applicationJsonUtf8Value = "application/json;charset=UTF-8"
+	defaultIssueType         = "Bug"
+	defaultResolveTransition = "Resolve"
+	statusCategoryDone       = "done"
+	queueSize                = 256
+	maxSummaryLength         = 250
+)
+
+func NewJiraNotification(fatimaRuntime fatima.FatimaRuntime) *JiraNotification {
+	jira := JiraNotification{}
+	jira.fatimaRuntime = fatimaRuntime
+	jira.mutex = &sync.Mutex{}
+	jira.client = &http.Client{Timeout: time.Second * 10}
+	jira.issues = make(map[string]JiraIssue)
+	jira.queue = make(chan jiraJob, queueSize)
+	jira.loadIssues()
+
+	go jira.run()
+	return &jira
+}
+
+// JiraNotification opens a jira issue for MAJOR alarm and resolves it when process startup again
+type JiraNotification struct {
+	fatimaRuntime   fatima.FatimaRuntime
+	lastLoadingTime time.Time
+	config          JiraConfig
+	mutex           *sync.Mutex
+	client          *http.Client
+	issues          map[string]JiraIssue // footprint -> opened issue
+	queue           chan jiraJob
+}
+
+type jiraJob struct {
+	mbus domain.MBusMessageBody
+	done func(err error)
+}
+
+type JiraConfig struct {
+	Active            bool     `json:"active"`
+	Url               string   `json:"url"`
+	User              string   `json:"user"`
+	Token             string   `json:"token"`
+	Project           string   `json:"project"`
+	IssueType         string   `json:"issue_type"`
+	ResolveTransition string   `json:"resolve_transition"`
+	Labels            []string `json:"labels,omitempty"`
+}
+
+type JiraIssue struct {
+	Key       string `json:"key"`
+	Source    string `json:"source"`
+	CreatedAt int    `json:"created_at"`
+}
+
+func (j *JiraNotification) SendNotify(mbus domain.MBusMessageBody) {
+	j.SendNotifyWithAck(mbus, nil)
+}
+
+// SendNotifyWithAck calls done after jira issue is created or commented
+func (j *JiraNotification) SendNotifyWithAck(mbus domain.MBusMessageBody, done func(err error)) {
+	if !mbus.IsAlarm() || (!mbus.IsMajorAlarm() && !mbus.IsProcessStartup()) {
+		finish(done, nil)
+		return
+	}
+
+	select {
+	case j.queue <- jiraJob{mbus: mbus, done: done}:
+	default:
+		log.Warn("jira queue is full. drop message : %s", mbus.GetSourceKey())
+		finish(done, fmt.Errorf("jira %w", domain.ErrQueueFull))
+	}
+}
+
+// IsQueueFull returns true when new message would be dropped
+func (j *JiraNotification) IsQueueFull() bool {
+	return len(j.queue) >= cap(j.queue)
+}
+
+func (j *JiraNotification) run() {
+	for job := range j.queue {
+		config, ok := j.getConfig()
+		if !ok {
+			finish(job.done, nil)
+			continue
+		}
+
+		if job.mbus.IsProcessStartup() {
+			j.resolveIssues(config, job.mbus)
+			finish(job.done, nil)
+			continue
+		}
+		finish(job.done, j.openOrComment(config, job.mbus))
+	}
+}
+
+func (j *JiraNotification) getConfig() (JiraConfig, bool) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	deadline := time.Now().Add(-time.Second * 10)
+	if j.lastLoadingTime.Before(deadline) {
+		j.loading()
+	}
+	if !j.config.Active || len(j.config.Url) < 6 || len(j.config.Project) == 0 {
+		return j.config, false
+	}
+	return j.config, true
+}
+
+func (j *JiraNotification) loading() {
+	j.lastLoadingTime = time.Now()
+	if j.fatimaRuntime == nil {
+		log.Warn("fatimaRuntime is nil")
+		return
+	}
+
+	configFile := filepath.Join(j.fatimaRuntime.GetEnv().GetFolderGuide().GetDataFolder(), fileApiJira)
+	dataBytes, err := os.ReadFile(configFile)
+	if err != nil {
+		return
+	}
+
+	var config JiraConfig
+	err = json.Unmarshal(dataBytes, &config)
+	if err != nil {
+		log.Warn("fail to unmarshal %s : %s", fileApiJira, err.Error())
+		return
+	}
+
+	if len(config.IssueType) == 0 {
+		config.IssueType = defaultIssueType
+	}
+	if len(config.ResolveTransition) == 0 {
+		config.ResolveTransition = defaultResolveTransition
+	}
+	config.Url = strings.TrimRight(config.Url, "/")
+	j.config = config
+	log.Debug("jira config loaded : url=%s, project=%s, active=%v", config.Url, config.Project, config.Active)
+}
+
+func (j *JiraNotification) openOrComment(config JiraConfig, mbus domain.MBusMessageBody) error {
+	if mbus.IsRepeatSummary() {
+		j.commentRepeat(config, mbus)
+		return nil
+	}
+
+	footprint := mbus.GetHashsum()
+	issue, ok := j.issues[footprint]
+	if ok {
+		open, err := j.isIssueOpen(config, issue.Key)
+		if err != nil {
+			log.Warn("fail to check jira issue %s : %s", issue.Key, err.Error())
+		}
+		if open || err != nil {
+			err = j.addComment(config, issue.Key, mbus)
+			if err != nil {
+				log.Warn("fail to comment jira issue %s : %s", issue.Key, err.Error())
+			}
+			return err
+		}
+		// closed by someone. open new one
+		delete(j.issues, footprint)
+	}
+
+	key, err := j.createIssue(config, mbus)
+	if err != nil {
+		log.Warn("fail to create jira issue : %s", err.Error())
+		return err
+	}
+
+	log.Info("jira issue %s created for %s", key, mbus.GetSourceKey())
+	j.issues[footprint] = JiraIssue{Key: key, Source: mbus.GetSourceKey(), CreatedAt: mbus.EventTime}
+	j.storeIssues()
+	return nil
+}
+
+// commentRepeat adds repeat summary to open issues of the same process
+func (j *JiraNotification) commentRepeat(config JiraConfig, mbus domain.MBusMessageBody) {
+	source := mbus.GetSourceKey()
+	for _, issue := range j.issues {
+		if issue.Source != source {
+			continue
+		}
+		err := j.addComment(config, issue.Key, mbus)
+		if err != nil {
+			log.Warn("fail to comment jira issue %s : %s", issue.Key, err.Error())
+		}
+	}
+}
+
+func (j *JiraNotification) resolveIssues(config JiraConfig, mbus domain.MBusMessageBody) {
+	source := mbus.GetSourceKey()
+	resolved := 0
+	for footprint, issue := range j.issues {
+		if issue.Source != source {
+			continue
+		}
+
+		err := j.transitIssue(config, issue.Key, fmt.Sprintf("%s started up again", mbus.PackageProcess))
+		if err != nil {
+			// keep mapping to retry at next startup
+			log.Warn("fail to resolve jira issue %s : %s", issue.Key, err.Error())
+			continue
+		}
+		log.Info("jira issue %s resolved", issue.Key)
+		delete(j.issues, footprint)
+		resolved++
+	}
+
+	if resolved > 0 {
+		j.storeIssues()
+	}
+}
+
+func (j *JiraNotification) createIssue(config JiraConfig, mbus domain.MBusMessageBody) (string, error) {
+	fields := make(map[string]interface{})
+	fields["project"] = map[string]string{"key": config.Project}
+	fields["issuetype"] = map[string]string{"name": config.IssueType}
+	fields["summary"] = buildSummary(mbus)
+	fields["description"] = buildDescription(mbus)
+	if len(config.Labels) > 0 {
+		fields["labels"] = config.Labels
+	}
+
+	var created struct {
+		Key string `json:"key"`
+	}
+	err := j.call(config, http.MethodPost, "/rest/api/2/issue", map[string]interface{}{"fields": fields}, &created)
+	if err != nil {
+		return "", err
+	}
+	return created.Key, nil
+}
+
+func (j *JiraNotification) addComment(config JiraConfig, issueKey string, mbus domain.MBusMessageBody) error {
+	body := map[string]string{
+		"body": fmt.Sprintf("occurred again at %s\n\n%s", formatEventTime(mbus.EventTime), mbus.GetText()),
+	}
+	return j.call(config, http.MethodPost, fmt.Sprintf("/rest/api/2/issue/%s/comment", issueKey), body, nil)
+}
+
+func (j *JiraNotification) isIssueOpen(config JiraConfig, issueKey string) (bool, error) {
+	var issue struct {
+		Fields struct {
+			Status struct {
+				StatusCategory struct {
+					Key string `json:"key"`
+				} `json:"statusCategory"`
+			} `json:"status"`
+		} `json:"fields"`
+	}
+	err := j.call(config, http.MethodGet, fmt.Sprintf("/rest/api/2/issue/%s?fields=status", issueKey), nil, &issue)
+	if err != nil {
+		return false, err
+	}
+	return issue.Fields.Status.StatusCategory.Key != statusCategoryDone, nil
+}
+
+func (j *JiraNotification) transitIssue(config JiraConfig, issueKey string, comment string) error {
+	var list struct {
+		Transitions []struct {
+			Id   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"transitions"`
+	}
+	path := fmt.Sprintf("/rest/api/2/issue/%s/transitions", issueKey)
+	err := j.call(config, http.MethodGet, path, nil, &list)
+	if err != nil {
+		return err
+	}
+
+	transitionId := ""
+	for _, t := range list.Transitions {
+		if t.Id == config.ResolveTransition || strings.EqualFold(t.Name, config.ResolveTransition) {
+			transitionId = t.Id
+			break
+		}
+	}
+	if len(transitionId) == 0 {
+		return fmt.Errorf("transition %s is not available", config.ResolveTransition)
+	}
+
+	body := map[string]interface{}{
+		"transition": map[string]string{"id": transitionId},
+		"update": map[string]interface{}{
+			"comment": []interface{}{map[string]interface{}{"add": map[string]string{"body": comment}}},
+		},
+	}
+	return j.call(config, http.MethodPost, path, body, nil)
+}
+
+func (j *JiraNotification) call(config JiraConfig, method, path string, body interface{}, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewBuffer(b)
+	}
+
+	req, err := http.NewRequest(method, config.Url+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", applicationJsonUtf8Value)
+	req.Header.Set("Accept", "application/json")
+	if len(config.User) > 0 {
+		req.SetBasicAuth(config.User, config.Token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+config.Token)
+	}
+
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("jira response %s : %s", resp.Status, string(b))
+	}
+
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func (j *JiraNotification) loadIssues() {
+	if j.fatimaRuntime == nil {
+		return
+	}
+
+	dataBytes, err := os.ReadFile(j.issueFilePath())
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(dataBytes, &j.issues)
+	if err != nil {
+		log.Warn("fail to unmarshal %s : %s", fileIssueJira, err.Error())
+		j.issues = make(map[string]JiraIssue)
+		return
+	}
+	log.Info("jira open issues loaded : %d", len(j.issues))
+}
+
+func (j *JiraNotification) storeIssues() {
+	if j.fatimaRuntime == nil {
+		return
+	}
+
+	b, err := json.Marshal(j.issues)
+	if err != nil {
+		log.Warn("fail to build json : %s", err.Error())
+		return
+	}
+
+	err = os.WriteFile(j.issueFilePath(), b, 0644)
+	if err != nil {
+		log.Warn("fail to store %s : %s", fileIssueJira, err.Error())
+	}
+}
+
+func (j *JiraNotification) issueFilePath() string {
+	return filepath.Join(j.fatimaRuntime.GetEnv().GetFolderGuide().GetDataFolder(), fileIssueJira)
+}
+
+func buildSummary(mbus domain.MBusMessageBody) string {
+	text := strings.TrimSpace(mbus.GetText())
+	if idx := strings.IndexByte(text, '\n'); idx > 0 {
+		text = text[:idx]
+	}
+
+	summary := fmt.Sprintf("%s : %s", mbus.GetSourceKey(), text)
+	if len(mbus.PackageProfile) > 0 {
+		summary = fmt.Sprintf("[%s] %s", mbus.PackageProfile, summary)
+	}
+
+	// cut by rune not to break multibyte character
+	if runes := []rune(summary); len(runes) > maxSummaryLength {
+		summary = string(runes[:maxSummaryLength])
+	}
+	return summary
+}
+
+func buildDescription(mbus domain.MBusMessageBody) string {
+	var buff bytes.Buffer
+	buff.WriteString(mbus.GetText())
+	buff.WriteString("\n\n")
+	buff.WriteString(fmt.Sprintf("profile : %s\n", mbus.PackageProfile))
+	buff.WriteString(fmt.Sprintf("group : %s\n", mbus.PackageGroup))
+	buff.WriteString(fmt.Sprintf("host : %s\n", mbus.PackageHost))
+	buff.WriteString(fmt.Sprintf("process : %s\n", mbus.PackageProcess))
+	buff.WriteString(fmt.Sprintf("event time : %s\n", formatEventTime(mbus.EventTime)))
+	if category := mbus.GetCategory(); len(category) > 0 {
+		buff.WriteString(fmt.Sprintf("category : %s\n", category))
+	}
+	return buff.String()
+}
+
+func formatEventTime(eventTime int) string {
+	return time.UnixMilli(int64(eventTime)).Format("2006-01-02 15:04:05")
+}
+
+// finish calls done callback if exists
+func finish(done func(err error), err error) {
+	if done != nil {
+		done(err)
+	}
+}
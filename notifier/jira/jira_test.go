@@ -0,0 +1,179 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 19. 오후 8:40
+ */
+
+package jira
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fatima-go/saturn/domain"
+)
+
+type jiraApiCall struct {
+	method  string
+	path    string
+	payload map[string]interface{}
+}
+
+// jiraApiStandIn is local stand-in of jira rest api which records calls
+type jiraApiStandIn struct {
+	mutex  sync.Mutex
+	calls  []jiraApiCall
+	status string // status category of opened issues
+	issues int
+}
+
+func (s *jiraApiStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	call := jiraApiCall{method: r.Method, path: r.URL.Path}
+	if r.Method == http.MethodPost {
+		json.NewDecoder(r.Body).Decode(&call.payload)
+	}
+	s.calls = append(s.calls, call)
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue":
+		s.issues++
+		fmt.Fprintf(w, `{"key":"OPS-%d"}`, s.issues)
+	case r.Method == http.MethodGet && r.URL.Query().Get("fields") == "status":
+		fmt.Fprintf(w, `{"fields":{"status":{"statusCategory":{"key":"%s"}}}}`, s.status)
+	case r.Method == http.MethodGet:
+		w.Write([]byte(`{"transitions":[{"id":"21","name":"In Progress"},{"id":"31","name":"Resolve"}]}`))
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *jiraApiStandIn) lastCall() jiraApiCall {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.calls[len(s.calls)-1]
+}
+
+func buildJiraMessage(action, text string) domain.MBusMessageBody {
+	return domain.MBusMessageBody{
+		EventTime:      1700000000000,
+		PackageGroup:   "test_group",
+		PackageHost:    "test_host",
+		PackageName:    "default",
+		PackageProcess: "test",
+		Message: map[string]interface{}{
+			domain.MessageKeyType:       domain.NotifyAlarm,
+			domain.MessageKeyAlarmLevel: domain.AlarmLevelMajor,
+			domain.MessageKeyAction:     action,
+			domain.MessageKeyMessage:    text,
+		},
+	}
+}
+
+func sendJira(t *testing.T, jira *JiraNotification, mbus domain.MBusMessageBody) error {
+	result := make(chan error, 1)
+	jira.SendNotifyWithAck(mbus, func(err error) { result <- err })
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(time.Second * 5):
+		t.Fatalf("jira ack timeout")
+	}
+	return nil
+}
+
+func TestJiraIssueLifecycle(t *testing.T) {
+	standIn := &jiraApiStandIn{status: "indeterminate"}
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	jira := NewJiraNotification(nil)
+	jira.mutex.Lock()
+	jira.lastLoadingTime = time.Now()
+	jira.config = JiraConfig{Active: true, Url: server.URL, Token: "secret", Project: "OPS", IssueType: defaultIssueType, ResolveTransition: defaultResolveTransition}
+	jira.mutex.Unlock()
+
+	// first alarm opens issue
+	alarm := buildJiraMessage("", "fail to connect database")
+	if err := sendJira(t, jira, alarm); err != nil {
+		t.Fatalf("fail to open issue : %s", err.Error())
+	}
+	call := standIn.lastCall()
+	if call.path != "/rest/api/2/issue" || call.payload["fields"] == nil {
+		t.Fatalf("issue should be created : %v", call)
+	}
+	fields := call.payload["fields"].(map[string]interface{})
+	if fields["summary"] != "test_group:test_host:test : fail to connect database" {
+		t.Fatalf("unexpected summary : %v", fields["summary"])
+	}
+
+	// same alarm is commented to the open issue
+	if err := sendJira(t, jira, alarm); err != nil {
+		t.Fatalf("fail to comment issue : %s", err.Error())
+	}
+	if call = standIn.lastCall(); call.path != "/rest/api/2/issue/OPS-1/comment" {
+		t.Fatalf("same alarm should be commented : %v", call)
+	}
+
+	// issue closed by someone. new one is opened
+	standIn.mutex.Lock()
+	standIn.status = statusCategoryDone
+	standIn.mutex.Unlock()
+	if err := sendJira(t, jira, alarm); err != nil {
+		t.Fatalf("fail to reopen issue : %s", err.Error())
+	}
+	if call = standIn.lastCall(); call.path != "/rest/api/2/issue" || len(jira.issues) != 1 || jira.issues[alarm.GetHashsum()].Key != "OPS-2" {
+		t.Fatalf("closed issue should be replaced : %v, issues=%v", call, jira.issues)
+	}
+
+	// startup resolves issue by transition
+	if err := sendJira(t, jira, buildJiraMessage(domain.ActionProcessStartup, "process test startup")); err != nil {
+		t.Fatalf("fail to resolve issue : %s", err.Error())
+	}
+	call = standIn.lastCall()
+	if call.method != http.MethodPost || call.path != "/rest/api/2/issue/OPS-2/transitions" {
+		t.Fatalf("startup should transit issue : %v", call)
+	}
+	if transition := call.payload["transition"].(map[string]interface{}); transition["id"] != "31" {
+		t.Fatalf("resolve transition should be used : %v", transition)
+	}
+	if len(jira.issues) != 0 {
+		t.Fatalf("resolved issue should be removed : %v", jira.issues)
+	}
+}
+
+func TestJiraQueueFull(t *testing.T) {
+	jira := &JiraNotification{queue: make(chan jiraJob, 1)}
+	jira.queue <- jiraJob{}
+	if !jira.IsQueueFull() {
+		t.Fatalf("queue should be full")
+	}
+
+	var result error
+	jira.SendNotifyWithAck(buildJiraMessage("", "fail to connect database"), func(err error) { result = err })
+	if !errors.Is(result, domain.ErrQueueFull) {
+		t.Fatalf("message should be rejected when queue is full : %v", result)
+	}
+}
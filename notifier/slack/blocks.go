@@ -0,0 +1,161 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 24. 오전 10:15
+ */
+
+package slack
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatima-go/saturn/domain"
+)
+
+const (
+	formatLegacy = "legacy"
+	formatBlocks = "blocks"
+
+	maxHeaderLength  = 150
+	maxSectionLength = 3000
+)
+
+// buildBlocks renders message with block kit
+// header (profile/group/host), section (text), context (process, event time, deploy user),
+// fields (git branch/commit) and fmon button when build info exists
+// alarm buttons (acknowledge, silence, snooze) are added when slack interaction is enabled
+func (s *SlackNotification) buildBlocks(mbus domain.MBusMessageBody, status string) []interface{} {
+	blocks := make([]interface{}, 0)
+	blocks = append(blocks, map[string]interface{}{
+		"type": "header",
+		"text": plainText(truncate(buildPretext(mbus), maxHeaderLength)),
+	})
+
+	text := mbus.GetText()
+	if mbus.IsAlarm() {
+		text = fmt.Sprintf("%s *%s*\n%s", levelEmoji(mbus.GetAlarmLevel()), mbus.GetAlarmLevel(), text)
+	}
+	blocks = append(blocks, map[string]interface{}{
+		"type": "section",
+		"text": markdownText(truncate(text, maxSectionLength)),
+	})
+
+	elements := []interface{}{
+		markdownText(fmt.Sprintf("process *%s*", mbus.PackageProcess)),
+		markdownText(fmt.Sprintf("event time %s", formatEventTime(mbus.EventTime))),
+	}
+
+	dep := mbus.GetDeployment()
+	hasBuild := mbus.IsAlarm() && mbus.IsProcessStartup() && dep.Valid && dep.HasBuildInfo()
+	if hasBuild {
+		elements = append(elements, markdownText(fmt.Sprintf("deploy user *%s*", dep.Build.BuildUser)))
+	}
+	blocks = append(blocks, map[string]interface{}{
+		"type":     "context",
+		"elements": elements,
+	})
+
+	if hasBuild && dep.Build.HasGit() {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "section",
+			"fields": []interface{}{
+				markdownText(fmt.Sprintf("*branch*\n%s", dep.Build.Git.Branch)),
+				markdownText(fmt.Sprintf("*commit*\n%s", dep.Build.Git.Commit)),
+			},
+		})
+
+		if len(s.fmonUrl) > 10 {
+			blocks = append(blocks, map[string]interface{}{
+				"type": "actions",
+				"elements": []interface{}{
+					map[string]interface{}{
+						"type": "button",
+						"text": plainText("배포 히스토리 보기"),
+						"url":  fmt.Sprintf(s.fmonUrl, mbus.PackageHost, mbus.PackageProcess),
+					},
+				},
+			})
+		}
+	}
+
+	if alarmId := mbus.GetAlarmId(); s.interactive && len(status) == 0 && len(alarmId) > 0 {
+		blocks = append(blocks, buildActions(alarmId))
+	}
+
+	if len(status) > 0 {
+		blocks = append(blocks, map[string]interface{}{
+			"type":     "context",
+			"elements": []interface{}{markdownText(fmt.Sprintf("status : *%s*", status))},
+		})
+	}
+	return blocks
+}
+
+// buildFallbackText is notification text of block kit message
+func buildFallbackText(mbus domain.MBusMessageBody) string {
+	text := firstLine(mbus.GetText())
+	if mbus.IsAlarm() {
+		text = fmt.Sprintf("[%s] %s", mbus.GetAlarmLevel(), text)
+	}
+	return fmt.Sprintf("%s %s", buildPretext(mbus), text)
+}
+
+func plainText(text string) map[string]interface{} {
+	return map[string]interface{}{"type": "plain_text", "text": text, "emoji": true}
+}
+
+func markdownText(text string) map[string]interface{} {
+	return map[string]interface{}{"type": "mrkdwn", "text": text}
+}
+
+func levelEmoji(level string) string {
+	switch level {
+	case domain.AlarmLevelMajor:
+		return ":red_circle:"
+	case domain.AlarmLevelMinor:
+		return ":large_blue_circle:"
+	case domain.AlarmLevelWarn:
+		return ":large_yellow_circle:"
+	}
+	return ":white_circle:"
+}
+
+func formatEventTime(eventTime int) string {
+	t := time.Now()
+	if eventTime > 0 {
+		t = time.UnixMilli(int64(eventTime))
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+func truncate(text string, limit int) string {
+	if len(text) == 0 {
+		return " "
+	}
+
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-3]) + "..."
+}
+
+func (s *SlackNotification) isBlocksFormat() bool {
+	return strings.EqualFold(s.format, formatBlocks)
+}
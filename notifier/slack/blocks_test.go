@@ -0,0 +1,130 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 19. 오후 9:50
+ */
+
+package slack
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fatima-go/saturn/domain"
+)
+
+func blockTypes(blocks []interface{}) []string {
+	types := make([]string, 0, len(blocks))
+	for _, b := range blocks {
+		types = append(types, b.(map[string]interface{})["type"].(string))
+	}
+	return types
+}
+
+func blockText(block interface{}) string {
+	return block.(map[string]interface{})["text"].(map[string]interface{})["text"].(string)
+}
+
+func contextTexts(block interface{}) []string {
+	texts := make([]string, 0)
+	for _, e := range block.(map[string]interface{})["elements"].([]interface{}) {
+		texts = append(texts, e.(map[string]interface{})["text"].(string))
+	}
+	return texts
+}
+
+func TestBuildBlocksAlarm(t *testing.T) {
+	s := &SlackNotification{format: formatBlocks, interactive: true}
+	mbus := buildBotMessage("", "fail to connect database")
+	mbus.PackageProfile = "prod"
+	mbus.Message[domain.MessageKeyAlarmId] = "alarm-0001"
+
+	blocks := s.buildBlocks(mbus, "")
+	if types := blockTypes(blocks); !reflect.DeepEqual(types, []string{"header", "section", "context", "actions"}) {
+		t.Fatalf("unexpected alarm layout : %v", types)
+	}
+	if header := blockText(blocks[0]); header != "[prod] test_group:test_host" {
+		t.Fatalf("unexpected header : %s", header)
+	}
+	if section := blockText(blocks[1]); section != ":red_circle: *MAJOR*\nfail to connect database" {
+		t.Fatalf("unexpected section : %s", section)
+	}
+	if texts := contextTexts(blocks[2]); len(texts) != 2 || texts[0] != "process *test*" {
+		t.Fatalf("unexpected context : %v", texts)
+	}
+	if actions := blocks[3].(map[string]interface{}); actions["block_id"] != blockIdActions {
+		t.Fatalf("alarm buttons should be added : %v", actions)
+	}
+
+	// status replaces buttons
+	blocks = s.buildBlocks(mbus, "acknowledged by jin")
+	if types := blockTypes(blocks); !reflect.DeepEqual(types, []string{"header", "section", "context", "context"}) {
+		t.Fatalf("unexpected alarm layout with status : %v", types)
+	}
+	if texts := contextTexts(blocks[3]); texts[0] != "status : *acknowledged by jin*" {
+		t.Fatalf("unexpected status : %v", texts)
+	}
+}
+
+func TestBuildBlocksStartupDeployment(t *testing.T) {
+	s := &SlackNotification{format: formatBlocks, fmonUrl: "http://fmon.local/deploy/%s/%s"}
+	mbus := buildBotMessage(domain.ActionProcessStartup, "process test startup")
+	mbus.Message[domain.MessageKeyAlarmLevel] = domain.AlarmLevelMinor
+	mbus.Message[domain.MessageKeyDeployment] = map[string]interface{}{
+		"process": "test",
+		"build": map[string]interface{}{
+			"time": "2026-10-19 20:00:00",
+			"user": "jin",
+			"git":  map[string]interface{}{"branch": "main", "commit": "0123abcd4567ef"},
+		},
+	}
+
+	blocks := s.buildBlocks(mbus, "")
+	if types := blockTypes(blocks); !reflect.DeepEqual(types, []string{"header", "section", "context", "section", "actions"}) {
+		t.Fatalf("unexpected startup layout : %v", types)
+	}
+	if texts := contextTexts(blocks[2]); len(texts) != 3 || texts[2] != "deploy user *jin*" {
+		t.Fatalf("deploy user should be in context : %v", texts)
+	}
+	fields := blocks[3].(map[string]interface{})["fields"].([]interface{})
+	if len(fields) != 2 || fields[0].(map[string]interface{})["text"] != "*branch*\nmain" || fields[1].(map[string]interface{})["text"] != "*commit*\n0123abcd4567ef" {
+		t.Fatalf("unexpected git fields : %v", fields)
+	}
+	button := blocks[4].(map[string]interface{})["elements"].([]interface{})[0].(map[string]interface{})
+	if button["url"] != "http://fmon.local/deploy/test_host/test" {
+		t.Fatalf("unexpected fmon button : %v", button)
+	}
+}
+
+func TestBuildBlocksRepeatSummary(t *testing.T) {
+	s := &SlackNotification{format: formatBlocks, interactive: true}
+	mbus := buildBotMessage("", "message repeated 12 times in 5m0s (first 20:00:00, last 20:05:00)\nfail to connect database")
+	mbus.Message[domain.MessageKeyAlarmLevel] = domain.AlarmLevelWarn
+	mbus.Message[domain.MessageKeyRepeatCount] = 12
+
+	blocks := s.buildBlocks(mbus, "")
+	if types := blockTypes(blocks); !reflect.DeepEqual(types, []string{"header", "section", "context"}) {
+		t.Fatalf("unexpected repeat summary layout : %v", types)
+	}
+	if section := blockText(blocks[1]); !strings.HasPrefix(section, ":large_yellow_circle: *WARN*\nmessage repeated 12 times") {
+		t.Fatalf("unexpected section : %s", section)
+	}
+	if fallback := buildFallbackText(mbus); fallback != "test_group:test_host [WARN] message repeated 12 times in 5m0s (first 20:00:00, last 20:05:00)" {
+		t.Fatalf("unexpected fallback text : %s", fallback)
+	}
+}
@@ -0,0 +1,432 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 23. 오후 5:40
+ */
+
+package slack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatima-go/fatima-core"
+	"github.com/fatima-go/fatima-log"
+	"github.com/fatima-go/saturn/domain"
+)
+
+const (
+	fileApiSlack          = "api.slack"
+	fileThreadSlack       = "thread.slack"
+	defaultApiUrl         = "https://slack.com/api"
+	defaultIncidentWindow = time.Hour * 6
+	botQueueSize          = 1024
+	botHttpTimeout        = time.Second * 10
+)
+
+// SlackBotConfig is the content of api.slack file in data folder.
+// when active, messages are sent with bot token (chat.postMessage) instead of webhook
+type SlackBotConfig struct {
+	Active         bool              `json:"active"`
+	Token          string            `json:"token"`
+	ApiUrl         string            `json:"api_url,omitempty"` // default https://slack.com/api
+	AlarmChannel   string            `json:"alarm_channel"`
+	EventChannel   string            `json:"event_channel,omitempty"`
+	Channels       map[string]string `json:"channels,omitempty"`        // category -> channel
+	IncidentWindow string            `json:"incident_window,omitempty"` // idle incident is closed after window. default 6h
+}
+
+// slackThread is incident of a process (group:host:process) in a channel.
+// following messages of the incident are posted as replies of the first message
+type slackThread struct {
+	Channel   string                 `json:"channel"`
+	Ts        string                 `json:"ts"`
+	Source    string                 `json:"source"`
+	AlarmIds  []string               `json:"alarm_ids,omitempty"`
+	Message   domain.MBusMessageBody `json:"message"` // first message of the incident
+	Replies   int                    `json:"replies"`
+	StartedAt int64                  `json:"started_at"`
+	LastAt    int64                  `json:"last_at"`
+}
+
+type botJob struct {
+	mbus  *domain.MBusMessageBody
+	alarm *domain.Alarm
+	done  func(err error)
+}
+
+type slackBot struct {
+	fatimaRuntime   fatima.FatimaRuntime
+	key             string
+	notification    *SlackNotification
+	mutex           sync.Mutex
+	config          SlackBotConfig
+	lastLoadingTime time.Time
+	client          *http.Client
+	queue           chan botJob
+	threads         map[string]*slackThread // channel|source -> incident thread
+	lastCallTime    time.Time
+}
+
+func newSlackBot(fatimaRuntime fatima.FatimaRuntime, key string, notification *SlackNotification) *slackBot {
+	bot := slackBot{}
+	bot.fatimaRuntime = fatimaRuntime
+	bot.key = key
+	bot.notification = notification
+	bot.client = &http.Client{Timeout: botHttpTimeout}
+	bot.queue = make(chan botJob, botQueueSize)
+	bot.threads = make(map[string]*slackThread)
+	bot.loadThreads()
+	go bot.run()
+	return &bot
+}
+
+// isActive returns true when bot token mode is configured
+func (b *slackBot) isActive() bool {
+	config := b.getConfig()
+	return config.Active && len(config.Token) > 0
+}
+
+func (b *slackBot) sendNotify(mbus domain.MBusMessageBody, done func(err error)) {
+	b.enqueue(botJob{mbus: &mbus, done: done})
+}
+
+func (b *slackBot) sendAlarmState(alarm domain.Alarm) {
+	b.enqueue(botJob{alarm: &alarm})
+}
+
+func (b *slackBot) enqueue(job botJob) {
+	select {
+	case b.queue <- job:
+	default:
+		log.Warn("slack[%s] bot queue is full. drop message", b.key)
+		finish(job.done, errQueueFull)
+	}
+}
+
+func (b *slackBot) isQueueFull() bool {
+	return len(b.queue) >= cap(b.queue)
+}
+
+func (b *slackBot) run() {
+	for job := range b.queue {
+		config := b.getConfig()
+		if job.mbus != nil {
+			finish(job.done, b.handleNotify(config, *job.mbus))
+		} else if job.alarm != nil {
+			b.handleAlarmState(config, *job.alarm)
+		}
+	}
+}
+
+func (b *slackBot) handleNotify(config SlackBotConfig, mbus domain.MBusMessageBody) error {
+	channel := config.getChannel(mbus)
+	if len(channel) == 0 {
+		return nil
+	}
+
+	payload := b.notification.buildSlackMessage(mbus, "")
+	if !mbus.IsAlarm() {
+		_, err := b.postMessage(config, channel, payload)
+		return err
+	}
+
+	now := time.Now()
+	key := channel + "|" + mbus.GetSourceKey()
+	thread, ok := b.threads[key]
+	if ok && now.Sub(time.UnixMilli(thread.LastAt)) > config.getIncidentWindow() {
+		delete(b.threads, key)
+		ok = false
+	}
+
+	if !ok && mbus.IsProcessStartup() {
+		// nothing to recover (e.g. deploy, restarted notice). startup opens no thread
+		_, err := b.postMessage(config, channel, payload)
+		return err
+	}
+
+	if !ok {
+		ts, err := b.postMessage(config, channel, payload)
+		if err != nil {
+			return err
+		}
+		thread = &slackThread{
+			Channel:   channel,
+			Ts:        ts,
+			Source:    mbus.GetSourceKey(),
+			Message:   mbus,
+			StartedAt: now.UnixMilli(),
+			LastAt:    now.UnixMilli(),
+		}
+		thread.addAlarmId(mbus.GetAlarmId())
+		b.threads[key] = thread
+		b.storeThreads()
+		return nil
+	}
+
+	// following message of the incident is thread reply
+	payload["thread_ts"] = thread.Ts
+	if _, err := b.postMessage(config, channel, payload); err != nil {
+		return err
+	}
+	thread.Replies++
+	thread.LastAt = now.UnixMilli()
+	thread.addAlarmId(mbus.GetAlarmId())
+
+	status := fmt.Sprintf("%d updates in thread. last : %s", thread.Replies, firstLine(mbus.GetText()))
+	if mbus.IsProcessStartup() {
+		// process is up again. close the incident
+		status = fmt.Sprintf("recovered at %s", now.Format("15:04:05"))
+		delete(b.threads, key)
+	}
+	b.updateMessage(config, thread, status)
+	b.storeThreads()
+	return nil
+}
+
+func (b *slackBot) handleAlarmState(config SlackBotConfig, alarm domain.Alarm) {
+	for key, thread := range b.threads {
+		if !thread.hasAlarmId(alarm.Id) {
+			continue
+		}
+
+		text := buildStateText(alarm)
+		b.postMessage(config, thread.Channel, map[string]interface{}{
+			"username":  userName,
+			"text":      text,
+			"thread_ts": thread.Ts,
+		})
+
+		status := fmt.Sprintf("%s by %s", alarm.State, alarm.UpdatedBy)
+		if !alarm.IsOpen() {
+			delete(b.threads, key)
+		}
+		b.updateMessage(config, thread, status)
+		b.storeThreads()
+		return
+	}
+
+	log.Debug("slack[%s] no thread for alarm %s", b.key, alarm.Id)
+}
+
+// updateMessage edits first message of the incident with status
+func (b *slackBot) updateMessage(config SlackBotConfig, thread *slackThread, status string) {
+	payload := b.notification.buildSlackMessage(thread.Message, status)
+	delete(payload, "username")
+	payload["channel"] = thread.Channel
+	payload["ts"] = thread.Ts
+	b.call(config, "chat.update", payload)
+}
+
+func (b *slackBot) postMessage(config SlackBotConfig, channel string, payload map[string]interface{}) (string, error) {
+	payload["channel"] = channel
+	return b.call(config, "chat.postMessage", payload)
+}
+
+// call invokes slack web api and returns ts of the message
+func (b *slackBot) call(config SlackBotConfig, method string, payload map[string]interface{}) (string, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Warn("fail to build json : %s", err.Error())
+		return "", err
+	}
+
+	// keep slack rate limit (about 1 message per second)
+	if elapsed := time.Since(b.lastCallTime); elapsed < sendInterval {
+		time.Sleep(sendInterval - elapsed)
+	}
+	b.lastCallTime = time.Now()
+
+	resp, err := postWithRetry(b.client, func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodPost, config.getApiUrl()+"/"+method, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", applicationJsonUtf8Value)
+		req.Header.Set("Authorization", "Bearer "+config.Token)
+		return req, nil
+	})
+	if err != nil {
+		log.Warn("fail to call slack %s : %s", method, err.Error())
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Ok    bool   `json:"ok"`
+		Ts    string `json:"ts"`
+		Error string `json:"error"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		log.Warn("slack %s response : %s", method, resp.Status)
+		return "", fmt.Errorf("invalid slack response : %s", resp.Status)
+	}
+	if !result.Ok {
+		log.Warn("slack %s error : %s", method, result.Error)
+		return "", fmt.Errorf("slack error : %s", result.Error)
+	}
+
+	log.Debug("successfully call slack %s : %d", method, len(body))
+	return result.Ts, nil
+}
+
+func (b *slackBot) getConfig() SlackBotConfig {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if time.Since(b.lastLoadingTime) > time.Second*10 {
+		b.loading()
+	}
+	return b.config
+}
+
+func (b *slackBot) loading() {
+	b.lastLoadingTime = time.Now()
+	if b.fatimaRuntime == nil {
+		return
+	}
+
+	dataBytes, err := os.ReadFile(b.dataFilePath(fileApiSlack))
+	if err != nil {
+		b.config = SlackBotConfig{}
+		return
+	}
+
+	var config SlackBotConfig
+	err = json.Unmarshal(dataBytes, &config)
+	if err != nil {
+		log.Warn("fail to unmarshal %s : %s", fileApiSlack, err.Error())
+		return
+	}
+	b.config = config
+}
+
+func (b *slackBot) loadThreads() {
+	if b.fatimaRuntime == nil {
+		return
+	}
+
+	dataBytes, err := os.ReadFile(b.dataFilePath(fileThreadSlack))
+	if err != nil {
+		return
+	}
+
+	var threads map[string]*slackThread
+	err = json.Unmarshal(dataBytes, &threads)
+	if err != nil {
+		log.Warn("fail to unmarshal %s : %s", fileThreadSlack, err.Error())
+		return
+	}
+	b.threads = threads
+	log.Info("slack[%s] threads loaded : %d", b.key, len(threads))
+}
+
+func (b *slackBot) storeThreads() {
+	if b.fatimaRuntime == nil {
+		return
+	}
+
+	data, err := json.MarshalIndent(b.threads, "", "  ")
+	if err != nil {
+		log.Warn("fail to build json : %s", err.Error())
+		return
+	}
+
+	err = os.WriteFile(b.dataFilePath(fileThreadSlack), data, 0644)
+	if err != nil {
+		log.Warn("fail to store %s : %s", fileThreadSlack, err.Error())
+	}
+}
+
+// dataFilePath returns path of file in data folder. {name}.{key} for non default key
+func (b *slackBot) dataFilePath(name string) string {
+	if len(b.key) > 0 && b.key != defaultKey {
+		name = name + "." + b.key
+	}
+	return filepath.Join(b.fatimaRuntime.GetEnv().GetFolderGuide().GetDataFolder(), name)
+}
+
+func (c SlackBotConfig) getApiUrl() string {
+	if len(c.ApiUrl) == 0 {
+		return defaultApiUrl
+	}
+	return strings.TrimRight(c.ApiUrl, "/")
+}
+
+func (c SlackBotConfig) getIncidentWindow() time.Duration {
+	if len(c.IncidentWindow) == 0 {
+		return defaultIncidentWindow
+	}
+
+	d, err := time.ParseDuration(c.IncidentWindow)
+	if err != nil {
+		log.Warn("invalid slack incident_window %s : %s", c.IncidentWindow, err.Error())
+		return defaultIncidentWindow
+	}
+	return d
+}
+
+// getChannel returns channel of message. category channel first, then alarm or event channel
+func (c SlackBotConfig) getChannel(mbus domain.MBusMessageBody) string {
+	if !mbus.IsAlarm() {
+		if len(c.EventChannel) > 0 {
+			return c.EventChannel
+		}
+		return c.AlarmChannel
+	}
+
+	cate := mbus.GetCategory()
+	if mbus.IsProcessStartupOrShutdown() && len(cate) == 0 {
+		cate = deployCategory
+	}
+	if channel, ok := c.Channels[cate]; ok && len(cate) > 0 {
+		return channel
+	}
+	return c.AlarmChannel
+}
+
+func (t *slackThread) addAlarmId(id string) {
+	if len(id) == 0 || t.hasAlarmId(id) {
+		return
+	}
+	t.AlarmIds = append(t.AlarmIds, id)
+}
+
+func (t *slackThread) hasAlarmId(id string) bool {
+	for _, v := range t.AlarmIds {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func firstLine(text string) string {
+	text = strings.TrimSpace(text)
+	if idx := strings.IndexByte(text, '\n'); idx > 0 {
+		return text[:idx]
+	}
+	return text
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 19. 오후 8:10
+ */
+
+package slack
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fatima-go/saturn/domain"
+)
+
+type slackApiCall struct {
+	method  string
+	payload map[string]interface{}
+}
+
+// newSlackApiStandIn returns local stand-in of slack web api which records calls and answers ts in order
+func newSlackApiStandIn(t *testing.T, calls *[]slackApiCall, mutex *sync.Mutex) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer xoxb-test" {
+			w.Write([]byte(`{"ok":false,"error":"invalid_auth"}`))
+			return
+		}
+
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("invalid payload : %s", err.Error())
+		}
+		mutex.Lock()
+		*calls = append(*calls, slackApiCall{method: strings.TrimPrefix(r.URL.Path, "/"), payload: payload})
+		ts := fmt.Sprintf("1700000000.%06d", len(*calls))
+		mutex.Unlock()
+		fmt.Fprintf(w, `{"ok":true,"ts":"%s"}`, ts)
+	}))
+}
+
+func buildBotMessage(action, text string) domain.MBusMessageBody {
+	return domain.MBusMessageBody{
+		EventTime:      1700000000000,
+		PackageGroup:   "test_group",
+		PackageHost:    "test_host",
+		PackageName:    "default",
+		PackageProcess: "test",
+		Message: map[string]interface{}{
+			domain.MessageKeyType:       domain.NotifyAlarm,
+			domain.MessageKeyAlarmLevel: domain.AlarmLevelMajor,
+			domain.MessageKeyAction:     action,
+			domain.MessageKeyMessage:    text,
+		},
+	}
+}
+
+func TestBotIncidentThread(t *testing.T) {
+	calls := make([]slackApiCall, 0)
+	var mutex sync.Mutex
+	server := newSlackApiStandIn(t, &calls, &mutex)
+	defer server.Close()
+
+	notification := &SlackNotification{key: defaultKey, mutex: &sync.Mutex{}, format: formatLegacy}
+	bot := &slackBot{key: defaultKey, notification: notification, client: server.Client(), threads: make(map[string]*slackThread)}
+	config := SlackBotConfig{Active: true, Token: "xoxb-test", ApiUrl: server.URL, AlarmChannel: "C0123ALARM"}
+
+	// shutdown opens incident
+	if err := bot.handleNotify(config, buildBotMessage(domain.ActionProcessShutdown, "process test shutdown")); err != nil {
+		t.Fatalf("fail to post shutdown : %s", err.Error())
+	}
+	if len(calls) != 1 || calls[0].method != "chat.postMessage" || calls[0].payload["thread_ts"] != nil {
+		t.Fatalf("shutdown should be posted as first message of incident : %v", calls)
+	}
+	if calls[0].payload["channel"] != "C0123ALARM" || len(bot.threads) != 1 {
+		t.Fatalf("shutdown should open thread in alarm channel : %v, threads=%d", calls[0].payload, len(bot.threads))
+	}
+	ts := "1700000000.000001"
+
+	// startup is replied to the incident and closes it
+	if err := bot.handleNotify(config, buildBotMessage(domain.ActionProcessStartup, "process test startup")); err != nil {
+		t.Fatalf("fail to post startup : %s", err.Error())
+	}
+	if len(calls) != 3 || calls[1].method != "chat.postMessage" || calls[1].payload["thread_ts"] != ts {
+		t.Fatalf("startup should be replied to the thread : %v", calls)
+	}
+	if calls[2].method != "chat.update" || calls[2].payload["ts"] != ts || calls[2].payload["channel"] != "C0123ALARM" {
+		t.Fatalf("first message should be updated : %v", calls[2])
+	}
+	if status := fmt.Sprintf("%v", calls[2].payload["attachments"]); !strings.Contains(status, "recovered at") {
+		t.Fatalf("updated message should have recovered status : %s", status)
+	}
+	if len(bot.threads) != 0 {
+		t.Fatalf("startup should close the incident : %d", len(bot.threads))
+	}
+
+	// restarted notice without open incident opens no thread
+	restarted := buildBotMessage(domain.ActionProcessStartup, "process test restarted (downtime 2.5s)")
+	restarted.Message[domain.MessageKeyDowntime] = 2500
+	if err := bot.handleNotify(config, restarted); err != nil {
+		t.Fatalf("fail to post restarted : %s", err.Error())
+	}
+	if len(calls) != 4 || calls[3].payload["thread_ts"] != nil || len(bot.threads) != 0 {
+		t.Fatalf("restarted notice should be plain message : %v, threads=%d", calls[3], len(bot.threads))
+	}
+}
@@ -0,0 +1,294 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 24. 오후 2:30
+ */
+
+package slack
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/fatima-go/fatima-core"
+	"github.com/fatima-go/fatima-log"
+	"github.com/fatima-go/saturn/domain"
+)
+
+const (
+	PropertyInteractionListen = "slack.interaction.listen"
+	PropertySigningSecret     = "slack.signing.secret"
+	PropertySnoozePeriod      = "slack.snooze.period"
+
+	blockIdActions     = "saturn_actions"
+	actionAcknowledge  = "saturn_ack"
+	actionSilence      = "saturn_silence"
+	actionSnooze       = "saturn_snooze"
+	silencePeriod      = time.Hour
+	defaultSnooze      = time.Hour * 4
+	maxRequestSkew     = 60 * 5 // seconds
+	maxInteractionBody = 1024 * 1024
+)
+
+// AlarmActionExecutor applies slack button actions to alarm state
+type AlarmActionExecutor interface {
+	GetAlarm(id string) (domain.Alarm, error)
+	AcknowledgeAlarm(id, by, comment string) (domain.Alarm, error)
+	CreateSilence(silence domain.Silence) (domain.Silence, error)
+}
+
+type interactionPayload struct {
+	Type string `json:"type"`
+	User struct {
+		Id       string `json:"id"`
+		Username string `json:"username"`
+		Name     string `json:"name"`
+	} `json:"user"`
+	Actions []struct {
+		ActionId string `json:"action_id"`
+		Value    string `json:"value"`
+	} `json:"actions"`
+	Message struct {
+		Text   string                   `json:"text"`
+		Blocks []map[string]interface{} `json:"blocks"`
+	} `json:"message"`
+	ResponseUrl string `json:"response_url"`
+}
+
+// InteractionHandler receives slack interaction payload (button click) and applies it to alarm
+type InteractionHandler struct {
+	executor      AlarmActionExecutor
+	signingSecret string
+	snoozePeriod  time.Duration
+	client        *http.Client
+}
+
+func NewInteractionHandler(fatimaRuntime fatima.FatimaRuntime, executor AlarmActionExecutor) *InteractionHandler {
+	handler := InteractionHandler{}
+	handler.executor = executor
+	handler.client = &http.Client{Timeout: botHttpTimeout}
+	handler.snoozePeriod = defaultSnooze
+
+	secret, err := fatimaRuntime.GetConfig().GetString(PropertySigningSecret)
+	if err == nil {
+		handler.signingSecret = secret
+	}
+	if handler.signingSecret == "" {
+		log.Warn("%s is not specified. every slack interaction will be rejected", PropertySigningSecret)
+	}
+
+	period, err := fatimaRuntime.GetConfig().GetString(PropertySnoozePeriod)
+	if err == nil {
+		if d, err := time.ParseDuration(period); err == nil {
+			handler.snoozePeriod = d
+		} else {
+			log.Warn("invalid %s %s : %s", PropertySnoozePeriod, period, err.Error())
+		}
+	}
+	return &handler
+}
+
+func (h *InteractionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxInteractionBody))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if !h.verify(r.Header, body, time.Now()) {
+		log.Warn("invalid slack signature from %s", r.RemoteAddr)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	var payload interactionPayload
+	err = json.Unmarshal([]byte(r.PostFormValue("payload")), &payload)
+	if err != nil {
+		log.Warn("fail to unmarshal slack interaction : %s", err.Error())
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// slack expects response within 3 seconds
+	w.WriteHeader(http.StatusOK)
+	if payload.Type != "block_actions" {
+		return
+	}
+	go h.apply(payload)
+}
+
+// verify checks X-Slack-Signature (v0=hmac_sha256(secret, v0:timestamp:body))
+func (h *InteractionHandler) verify(header http.Header, body []byte, now time.Time) bool {
+	if len(h.signingSecret) == 0 {
+		return false
+	}
+
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || math.Abs(float64(now.Unix()-ts)) > maxRequestSkew {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(h.signingSecret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(header.Get("X-Slack-Signature")))
+}
+
+func (h *InteractionHandler) apply(payload interactionPayload) {
+	by := "slack:" + payload.User.Username
+	if len(payload.User.Username) == 0 {
+		by = "slack:" + payload.User.Name
+	}
+
+	for _, action := range payload.Actions {
+		status, err := h.applyAction(action.ActionId, action.Value, by)
+		if err != nil {
+			log.Warn("fail to apply slack action %s on %s : %s", action.ActionId, action.Value, err.Error())
+			status = fmt.Sprintf("%s failed : %s", action.ActionId, err.Error())
+		}
+		if len(status) > 0 {
+			h.updateOriginal(payload, status)
+		}
+	}
+}
+
+func (h *InteractionHandler) applyAction(actionId, alarmId, by string) (string, error) {
+	switch actionId {
+	case actionAcknowledge:
+		alarm, err := h.executor.AcknowledgeAlarm(alarmId, by, "acknowledged in slack")
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s by %s", alarm.State, by), nil
+	case actionSilence:
+		alarm, err := h.executor.GetAlarm(alarmId)
+		if err != nil {
+			return "", err
+		}
+		// silence every alarm of the process
+		matcher := domain.MessageMatcher{
+			Group:   exactPattern(alarm.Message.PackageGroup),
+			Host:    exactPattern(alarm.Message.PackageHost),
+			Process: exactPattern(alarm.Message.PackageProcess),
+		}
+		return h.silence(matcher, silencePeriod, by, "silenced in slack")
+	case actionSnooze:
+		alarm, err := h.executor.GetAlarm(alarmId)
+		if err != nil {
+			return "", err
+		}
+		// silence only this alarm
+		matcher := domain.MessageMatcher{
+			Group:   exactPattern(alarm.Message.PackageGroup),
+			Host:    exactPattern(alarm.Message.PackageHost),
+			Process: exactPattern(alarm.Message.PackageProcess),
+			Message: exactPattern(alarm.Message.GetText()),
+		}
+		return h.silence(matcher, h.snoozePeriod, by, "snoozed in slack")
+	}
+	return "", nil
+}
+
+func (h *InteractionHandler) silence(matcher domain.MessageMatcher, period time.Duration, by, comment string) (string, error) {
+	now := int(time.Now().UnixMilli())
+	silence, err := h.executor.CreateSilence(domain.Silence{
+		Matcher:   matcher,
+		StartsAt:  now,
+		EndsAt:    now + int(period.Milliseconds()),
+		CreatedBy: by,
+		Comment:   comment,
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s by %s for %s (silence %s)", comment, by, period, silence.Id), nil
+}
+
+// updateOriginal replaces buttons of the original message with status through response_url
+func (h *InteractionHandler) updateOriginal(payload interactionPayload, status string) {
+	if len(payload.ResponseUrl) == 0 {
+		return
+	}
+
+	blocks := make([]interface{}, 0, len(payload.Message.Blocks)+1)
+	for _, b := range payload.Message.Blocks {
+		if b["block_id"] == blockIdActions {
+			continue
+		}
+		blocks = append(blocks, b)
+	}
+	blocks = append(blocks, map[string]interface{}{
+		"type":     "context",
+		"elements": []interface{}{markdownText(fmt.Sprintf("status : *%s*", status))},
+	})
+
+	body, err := json.Marshal(map[string]interface{}{
+		"replace_original": true,
+		"text":             payload.Message.Text,
+		"blocks":           blocks,
+	})
+	if err != nil {
+		log.Warn("fail to build json : %s", err.Error())
+		return
+	}
+
+	resp, err := h.client.Post(payload.ResponseUrl, applicationJsonUtf8Value, bytes.NewReader(body))
+	if err != nil {
+		log.Warn("fail to update slack message : %s", err.Error())
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		log.Info("slack response_url response : %s", resp.Status)
+	}
+}
+
+// buildActions returns buttons for alarm which saturn tracks
+func buildActions(alarmId string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":     "actions",
+		"block_id": blockIdActions,
+		"elements": []interface{}{
+			map[string]interface{}{"type": "button", "action_id": actionAcknowledge, "text": plainText("Acknowledge"), "value": alarmId, "style": "primary"},
+			map[string]interface{}{"type": "button", "action_id": actionSilence, "text": plainText("Silence 1h"), "value": alarmId},
+			map[string]interface{}{"type": "button", "action_id": actionSnooze, "text": plainText("Snooze"), "value": alarmId},
+		},
+	}
+}
+
+// exactPattern builds matcher pattern which matches the value only
+func exactPattern(value string) string {
+	return "~^" + regexp.QuoteMeta(value) + "$"
+}
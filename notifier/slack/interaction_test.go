@@ -0,0 +1,63 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 24. 오후 2:30
+ */
+
+package slack
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestVerifySignature(t *testing.T) {
+	handler := InteractionHandler{signingSecret: "8f742231b10e8888abcd99yyyzzz85a5"}
+	body := []byte("payload=%7B%22type%22%3A%22block_actions%22%7D")
+	now := time.Now()
+
+	sign := func(secret string, ts int64) http.Header {
+		timestamp := strconv.FormatInt(ts, 10)
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte("v0:" + timestamp + ":"))
+		mac.Write(body)
+		header := http.Header{}
+		header.Set("X-Slack-Request-Timestamp", timestamp)
+		header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+		return header
+	}
+
+	if !handler.verify(sign(handler.signingSecret, now.Unix()), body, now) {
+		t.Fatalf("valid signature should be verified")
+	}
+	if handler.verify(sign("wrong secret", now.Unix()), body, now) {
+		t.Fatalf("signature with wrong secret should be rejected")
+	}
+	if handler.verify(sign(handler.signingSecret, now.Add(-time.Minute*10).Unix()), body, now) {
+		t.Fatalf("old request should be rejected")
+	}
+
+	empty := InteractionHandler{}
+	if empty.verify(sign("", now.Unix()), body, now) {
+		t.Fatalf("request should be rejected without signing secret")
+	}
+}
@@ -0,0 +1,211 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 25. 오전 9:40
+ */
+
+package slack
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fatima-go/fatima-log"
+	"github.com/fatima-go/saturn/domain"
+)
+
+const (
+	sendQueueSize   = 256
+	sendInterval    = time.Second // slack allows about 1 message per second
+	sendHttpTimeout = time.Second * 10
+	maxSendAttempts = 5
+	initialBackoff  = time.Second
+	maxBackoff      = time.Second * 30
+	maxRetryAfter   = time.Minute * 5
+	dropLogInterval = time.Second * 10
+)
+
+var (
+	errQueueFull = fmt.Errorf("slack send %w", domain.ErrQueueFull)
+
+	sendQueueMutex sync.Mutex
+	sendQueues     = make(map[string]*sendQueue) // webhook url -> queue
+	sendClient     = &http.Client{Timeout: sendHttpTimeout}
+)
+
+type sendItem struct {
+	body []byte
+	done func(err error)
+}
+
+// sendQueue delivers messages to a webhook one by one with rate limit and retry
+type sendQueue struct {
+	url         string
+	queue       chan sendItem
+	dropped     int
+	lastDropLog time.Time
+	mutex       sync.Mutex
+}
+
+// enqueueSlack puts message to the queue of webhook url. message is dropped when the queue is full
+// done (optional) is called with the result of delivery
+func enqueueSlack(url string, b []byte, done func(err error)) {
+	q := getSendQueue(url)
+	select {
+	case q.queue <- sendItem{body: b, done: done}:
+	default:
+		q.drop()
+		finish(done, errQueueFull)
+	}
+}
+
+func getSendQueue(url string) *sendQueue {
+	sendQueueMutex.Lock()
+	defer sendQueueMutex.Unlock()
+
+	q, ok := sendQueues[url]
+	if !ok {
+		q = &sendQueue{url: url, queue: make(chan sendItem, sendQueueSize)}
+		sendQueues[url] = q
+		go q.run()
+	}
+	return q
+}
+
+// isSendQueueFull returns true when the queue of webhook url can not take message now
+func isSendQueueFull(url string) bool {
+	sendQueueMutex.Lock()
+	q, ok := sendQueues[url]
+	sendQueueMutex.Unlock()
+	return ok && len(q.queue) >= cap(q.queue)
+}
+
+func (q *sendQueue) run() {
+	for item := range q.queue {
+		started := time.Now()
+		finish(item.done, sendMessageToSlack(q.url, item.body))
+		if elapsed := time.Since(started); elapsed < sendInterval {
+			time.Sleep(sendInterval - elapsed)
+		}
+	}
+}
+
+func (q *sendQueue) drop() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.dropped++
+	if time.Since(q.lastDropLog) < dropLogInterval {
+		return
+	}
+	log.Warn("slack send queue is full. %d messages dropped : %s", q.dropped, maskUrl(q.url))
+	q.dropped = 0
+	q.lastDropLog = time.Now()
+}
+
+func sendMessageToSlack(url string, b []byte) error {
+	resp, err := postWithRetry(sendClient, func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", applicationJsonUtf8Value)
+		return req, nil
+	})
+	if err != nil {
+		log.Warn("fail to send slack notification : %s", err.Error())
+		return err
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		log.Debug("successfully send to slack : %d", len(b))
+		return nil
+	}
+	log.Info("slack response : %s", resp.Status)
+	return fmt.Errorf("slack response : %s", resp.Status)
+}
+
+// finish calls done callback if exists
+func finish(done func(err error), err error) {
+	if done != nil {
+		done(err)
+	}
+}
+
+// postWithRetry sends request and retries on 429 (waits Retry-After) and 5xx (exponential backoff)
+// the caller should close body of returned response
+func postWithRetry(client *http.Client, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	backoff := initialBackoff
+	var lastErr error
+	for attempt := 1; attempt <= maxSendAttempts; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Do(req)
+		wait := backoff
+		switch {
+		case err != nil:
+			lastErr = err
+		case resp.StatusCode == http.StatusTooManyRequests:
+			wait = getRetryAfter(resp.Header, backoff)
+			lastErr = fmt.Errorf("rate limited : %s", resp.Status)
+			drain(resp)
+		case resp.StatusCode >= 500:
+			lastErr = fmt.Errorf("server error : %s", resp.Status)
+			drain(resp)
+		default:
+			return resp, nil
+		}
+
+		if attempt == maxSendAttempts {
+			break
+		}
+		log.Info("slack send attempt %d failed (%s). retry after %s", attempt, lastErr.Error(), wait)
+		time.Sleep(wait)
+		backoff = min(backoff*2, maxBackoff)
+	}
+	return nil, &domain.AttemptError{Attempts: maxSendAttempts, Err: lastErr}
+}
+
+func getRetryAfter(header http.Header, defaultValue time.Duration) time.Duration {
+	seconds, err := strconv.Atoi(header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return defaultValue
+	}
+	return min(time.Duration(seconds)*time.Second, maxRetryAfter)
+}
+
+func drain(resp *http.Response) {
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
+
+// maskUrl hides secret path of webhook url in log
+func maskUrl(url string) string {
+	if len(url) > 40 {
+		return url[:40] + "..."
+	}
+	return url
+}
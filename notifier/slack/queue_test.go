@@ -0,0 +1,77 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 25. 오전 9:40
+ */
+
+package slack
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPostWithRetry(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer server.Close()
+
+	started := time.Now()
+	resp, err := postWithRetry(server.Client(), func() (*http.Request, error) {
+		return http.NewRequest(http.MethodPost, server.URL, nil)
+	})
+	if err != nil {
+		t.Fatalf("should succeed after retry : %s", err.Error())
+	}
+	resp.Body.Close()
+
+	if calls != 3 {
+		t.Fatalf("expected 3 calls but %d", calls)
+	}
+	// retry-after 1s, then backoff 2s
+	if elapsed := time.Since(started); elapsed < time.Second*3 {
+		t.Fatalf("retry should wait : %s", elapsed)
+	}
+}
+
+func TestGetRetryAfter(t *testing.T) {
+	header := http.Header{}
+	if getRetryAfter(header, time.Second) != time.Second {
+		t.Errorf("default should be used without Retry-After")
+	}
+	header.Set("Retry-After", "30")
+	if getRetryAfter(header, time.Second) != time.Second*30 {
+		t.Errorf("Retry-After should be used")
+	}
+	header.Set("Retry-After", "3600")
+	if getRetryAfter(header, time.Second) != maxRetryAfter {
+		t.Errorf("Retry-After should be limited")
+	}
+}
@@ -23,10 +23,10 @@ package slack
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"github.com/fatima-go/fatima-core"
 	"github.com/fatima-go/fatima-log"
 	"github.com/fatima-go/saturn/domain"
-	"net/http"
 	"os"
 	"path/filepath"
 	"sync"
@@ -35,27 +35,31 @@ import (
 
 const (
 	fileWebhookSlack      = "webhook.slack"
+	defaultKey            = "default"
 	attachmentsColorGreen = "#00FF00"
 	attachmentsColorRed   = "#FF0000"
 	attachmentColorOrange = "#FFA500"
 	// ##439fe0
 	attachmentColorBlue      = "#439FE0"
 	attachmentColorYellow    = "#FFFF00"
+	attachmentColorGray      = "#9E9E9E"
 	userName                 = "FATIMA"
 	footerIcon               = "https://platform.slack-edge.com/img/default_application_icon.png"
 	applicationJsonUtf8Value = "application/json;charset=UTF-8"
 	PropertyFmonUrl          = "fmon.url"
+	PropertyFormat           = "slack.format"
 
 	deployCategory = "deploy"
 )
 
 func NewSlackNotification(fatimaRuntime fatima.FatimaRuntime) *SlackNotification {
-	return NewSlackNotificationWithKey(fatimaRuntime, "default")
+	return NewSlackNotificationWithKey(fatimaRuntime, defaultKey)
 }
 
 func NewSlackNotificationWithKey(fatimaRuntime fatima.FatimaRuntime, key string) *SlackNotification {
 	slack := SlackNotification{}
 	slack.fatimaRuntime = fatimaRuntime
+	slack.key = key
 	slack.mutex = &sync.Mutex{}
 	slack.alarm.Active = false
 	slack.event.Active = false
@@ -67,18 +71,37 @@ func NewSlackNotificationWithKey(fatimaRuntime fatima.FatimaRuntime, key string)
 		slack.fmonUrl = fmonUrl
 	}
 
-	log.Info("slack.fmonUrl=[%s]", slack.fmonUrl)
+	// load message format. slack.format.{key} overrides slack.format
+	slack.format = formatLegacy
+	if format, err := fatimaRuntime.GetConfig().GetString(PropertyFormat); err == nil {
+		slack.format = format
+	}
+	if format, err := fatimaRuntime.GetConfig().GetString(PropertyFormat + "." + key); err == nil {
+		slack.format = format
+	}
+
+	// alarm buttons need interaction endpoint
+	if listen, err := fatimaRuntime.GetConfig().GetString(PropertyInteractionListen); err == nil && len(listen) > 0 {
+		slack.interactive = true
+	}
+
+	log.Info("slack[%s].fmonUrl=[%s], format=[%s], interactive=[%v]", key, slack.fmonUrl, slack.format, slack.interactive)
+	slack.bot = newSlackBot(fatimaRuntime, key, &slack)
 	return &slack
 }
 
 type SlackNotification struct {
 	fatimaRuntime   fatima.FatimaRuntime
+	key             string
 	lastLoadingTime time.Time
 	alarm           SlackConfig
 	event           SlackConfig
 	alarmCategory   map[string]SlackConfig
 	mutex           *sync.Mutex
 	fmonUrl         string
+	format          string
+	interactive     bool
+	bot             *slackBot
 }
 
 type SlackConfig struct {
@@ -92,17 +115,65 @@ func (s *SlackNotification) GetFmonUrl() string {
 }
 
 func (s *SlackNotification) SendNotify(mbus domain.MBusMessageBody) {
-	message := s.buildSlackMessage(mbus)
+	s.SendNotifyWithAck(mbus, nil)
+}
+
+// SendNotifyWithAck sends message and calls done with the result of slack response
+// message which slack is not configured for is regarded as delivered
+func (s *SlackNotification) SendNotifyWithAck(mbus domain.MBusMessageBody, done func(err error)) {
+	if s.bot.isActive() {
+		s.bot.sendNotify(mbus, done)
+		return
+	}
+
+	message := s.buildSlackMessage(mbus, "")
 	cate := mbus.GetCategory()
 
 	if mbus.IsAlarm() {
 		if mbus.IsProcessStartupOrShutdown() && len(cate) == 0 {
 			cate = deployCategory
 		}
-		s.sendAlarm(message, cate)
+		s.sendAlarm(message, cate, done)
 	} else {
-		s.sendEvent(message)
+		s.sendEvent(message, done)
+	}
+}
+
+// SendAlarmState posts state change of alarm (acknowledged, resolved, silenced) to the channel of the alarm
+// in bot mode, it is replied to the thread of the alarm
+func (s *SlackNotification) SendAlarmState(alarm domain.Alarm) {
+	if s.bot.isActive() {
+		s.bot.sendAlarmState(alarm)
+		return
 	}
+
+	mbus := alarm.Message
+	cate := mbus.GetCategory()
+	if mbus.IsProcessStartupOrShutdown() && len(cate) == 0 {
+		cate = deployCategory
+	}
+	s.sendAlarm(s.buildStateMessage(alarm), cate, nil)
+}
+
+// IsQueueFull returns true when bot queue, or send queue of any webhook of this notifier is full
+func (s *SlackNotification) IsQueueFull() bool {
+	if s.bot.isActive() {
+		return s.bot.isQueueFull()
+	}
+
+	s.mutex.Lock()
+	urls := []string{s.alarm.Url, s.event.Url}
+	for _, v := range s.alarmCategory {
+		urls = append(urls, v.Url)
+	}
+	s.mutex.Unlock()
+
+	for _, url := range urls {
+		if isSendQueueFull(url) {
+			return true
+		}
+	}
+	return false
 }
 
 func (s *SlackNotification) loading() {
@@ -112,7 +183,7 @@ func (s *SlackNotification) loading() {
 		return
 	}
 
-	webhookConfigFile := filepath.Join(s.fatimaRuntime.GetEnv().GetFolderGuide().GetDataFolder(), fileWebhookSlack)
+	webhookConfigFile := filepath.Join(s.fatimaRuntime.GetEnv().GetFolderGuide().GetDataFolder(), s.getWebhookFileName())
 	dataBytes, err := os.ReadFile(webhookConfigFile)
 	if err != nil {
 		return
@@ -150,6 +221,14 @@ func (s *SlackNotification) loading() {
 	log.Debug("slack config loaded : alarm[%v], event[%v], alarmCategory[%d]", s.alarm, s.event, len(s.alarmCategory))
 }
 
+// getWebhookFileName returns webhook.slack for default key, webhook.slack.{key} otherwise
+func (s *SlackNotification) getWebhookFileName() string {
+	if len(s.key) == 0 || s.key == defaultKey {
+		return fileWebhookSlack
+	}
+	return fileWebhookSlack + "." + s.key
+}
+
 func (s *SlackNotification) isEventWritable() bool {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
@@ -211,30 +290,31 @@ func (s *SlackNotification) getAlarmCategoryUrlAndChannel(cate string) (url stri
 	return config.Url, config.Channel
 }
 
-func (s *SlackNotification) sendEvent(m map[string]interface{}) {
+func (s *SlackNotification) sendEvent(m map[string]interface{}, done func(err error)) {
 	if !s.isEventWritable() {
+		finish(done, nil)
 		return
 	}
 
 	b, err := json.Marshal(m)
 	if err != nil {
 		log.Warn("fail to build json : %s", err.Error())
+		finish(done, err)
 		return
 	}
 
-	go func() {
-		sendMessageToSlack(s.event.Url, b)
-	}()
+	enqueueSlack(s.event.Url, b, done)
 }
 
-func (s *SlackNotification) sendAlarm(m map[string]interface{}, cate string) {
+func (s *SlackNotification) sendAlarm(m map[string]interface{}, cate string, done func(err error)) {
 	if len(cate) == 0 {
-		s.sendAlarmCase(m)
+		s.sendAlarmCase(m, done)
 		return
 	}
 
 	// send with category
 	if !s.isAlarmCategoryWritable(cate) {
+		finish(done, nil)
 		return
 	}
 
@@ -248,58 +328,50 @@ func (s *SlackNotification) sendAlarm(m map[string]interface{}, cate string) {
 		b, err := json.Marshal(m)
 		if err != nil {
 			log.Warn("fail to build json : %s", err.Error())
+			finish(done, err)
 			return
 		}
 
-		go func() {
-			log.Info("sending with cate %s, url=%s", cate, url)
-			sendMessageToSlack(url, b)
-		}()
+		log.Info("sending with cate %s", cate)
+		enqueueSlack(url, b, done)
+		return
 	}
+	finish(done, nil)
 }
 
-func (s *SlackNotification) sendAlarmCase(m map[string]interface{}) {
+func (s *SlackNotification) sendAlarmCase(m map[string]interface{}, done func(err error)) {
 	if !s.isAlarmWritable() {
+		finish(done, nil)
 		return
 	}
 
 	b, err := json.Marshal(m)
 	if err != nil {
 		log.Warn("fail to build json : %s", err.Error())
+		finish(done, err)
 		return
 	}
 
-	go func() {
-		sendMessageToSlack(s.alarm.Url, b)
-	}()
-}
-
-func sendMessageToSlack(url string, b []byte) {
-	resp, err := http.Post(url, applicationJsonUtf8Value, bytes.NewBuffer(b))
-	if err != nil {
-		log.Warn("fail to send slack notification : %s", err.Error())
-		return
-	}
-
-	defer resp.Body.Close()
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		log.Debug("successfully send to slack : %d", len(b))
-	} else {
-		log.Info("slack response : %s", resp.Status)
-	}
+	enqueueSlack(s.alarm.Url, b, done)
 }
 
-func (s *SlackNotification) buildSlackMessage(mbus domain.MBusMessageBody) map[string]interface{} {
+func (s *SlackNotification) buildSlackMessage(mbus domain.MBusMessageBody, status string) map[string]interface{} {
 	m := make(map[string]interface{})
 	m["username"] = userName
+	if s.isBlocksFormat() {
+		m["text"] = buildFallbackText(mbus)
+		m["blocks"] = s.buildBlocks(mbus, status)
+		return m
+	}
+
 	list := make([]interface{}, 0)
-	list = append(list, s.buildAttachment(mbus))
+	list = append(list, s.buildAttachment(mbus, status))
 	m["attachments"] = list
 
 	return m
 }
 
-func (s *SlackNotification) buildAttachment(mbus domain.MBusMessageBody) map[string]interface{} {
+func (s *SlackNotification) buildAttachment(mbus domain.MBusMessageBody, status string) map[string]interface{} {
 	m := make(map[string]interface{})
 	m["pretext"] = buildPretext(mbus)
 	m["color"] = attachmentsColorGreen
@@ -318,12 +390,52 @@ func (s *SlackNotification) buildAttachment(mbus domain.MBusMessageBody) map[str
 		}
 	}
 	m["text"] = mbus.GetMessageText(s.GetFmonUrl())
+	if len(status) > 0 {
+		m["fields"] = []interface{}{map[string]interface{}{"title": "status", "value": status, "short": false}}
+	}
 	m["footer"] = mbus.PackageProcess
 	m["footer_icon"] = footerIcon
 	m["ts"] = mbus.EventTime / 1000
 	return m
 }
 
+func (s *SlackNotification) buildStateMessage(alarm domain.Alarm) map[string]interface{} {
+	attachment := make(map[string]interface{})
+	attachment["pretext"] = buildPretext(alarm.Message)
+	attachment["color"] = attachmentColorGray
+	switch alarm.State {
+	case domain.AlarmStateAcknowledged:
+		attachment["color"] = attachmentColorOrange
+	case domain.AlarmStateResolved:
+		attachment["color"] = attachmentsColorGreen
+	}
+	attachment["text"] = buildStateText(alarm)
+	attachment["footer"] = alarm.Message.PackageProcess
+	attachment["footer_icon"] = footerIcon
+	attachment["ts"] = alarm.UpdatedAt / 1000
+
+	m := make(map[string]interface{})
+	m["username"] = userName
+	m["attachments"] = []interface{}{attachment}
+	return m
+}
+
+func buildStateText(alarm domain.Alarm) string {
+	var buff bytes.Buffer
+	buff.WriteString(fmt.Sprintf("alarm %s %s by %s", alarm.Id, alarm.State, alarm.UpdatedBy))
+	if len(alarm.Comment) > 0 {
+		buff.WriteString(" : ")
+		buff.WriteString(alarm.Comment)
+	}
+
+	text := firstLine(alarm.Message.GetText())
+	if len(text) > 0 {
+		buff.WriteString("\n> ")
+		buff.WriteString(text)
+	}
+	return buff.String()
+}
+
 func buildPretext(mbus domain.MBusMessageBody) string {
 	var buff bytes.Buffer
 	if len(mbus.PackageProfile) > 0 {
@@ -0,0 +1,2134 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: saturn.admin.v1.proto
+
+package saturn_admin_v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// glob pattern or regular expression which starts with '~'. empty field matches everything
+type MessageMatcher struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profile       string                 `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	Group         string                 `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	Host          string                 `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
+	Process       string                 `protobuf:"bytes,4,opt,name=process,proto3" json:"process,omitempty"`
+	AlarmLevel    string                 `protobuf:"bytes,5,opt,name=alarmLevel,proto3" json:"alarmLevel,omitempty"`
+	Type          string                 `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`
+	Action        string                 `protobuf:"bytes,7,opt,name=action,proto3" json:"action,omitempty"`
+	Category      string                 `protobuf:"bytes,8,opt,name=category,proto3" json:"category,omitempty"`
+	Message       string                 `protobuf:"bytes,9,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageMatcher) Reset() {
+	*x = MessageMatcher{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageMatcher) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageMatcher) ProtoMessage() {}
+
+func (x *MessageMatcher) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageMatcher.ProtoReflect.Descriptor instead.
+func (*MessageMatcher) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MessageMatcher) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *MessageMatcher) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *MessageMatcher) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *MessageMatcher) GetProcess() string {
+	if x != nil {
+		return x.Process
+	}
+	return ""
+}
+
+func (x *MessageMatcher) GetAlarmLevel() string {
+	if x != nil {
+		return x.AlarmLevel
+	}
+	return ""
+}
+
+func (x *MessageMatcher) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *MessageMatcher) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *MessageMatcher) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *MessageMatcher) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// time values are unix epoch milliseconds
+type Silence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Matcher       *MessageMatcher        `protobuf:"bytes,2,opt,name=matcher,proto3" json:"matcher,omitempty"`
+	StartsAt      int64                  `protobuf:"varint,3,opt,name=startsAt,proto3" json:"startsAt,omitempty"`
+	EndsAt        int64                  `protobuf:"varint,4,opt,name=endsAt,proto3" json:"endsAt,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,5,opt,name=createdBy,proto3" json:"createdBy,omitempty"`
+	Comment       string                 `protobuf:"bytes,6,opt,name=comment,proto3" json:"comment,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,7,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Silence) Reset() {
+	*x = Silence{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Silence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Silence) ProtoMessage() {}
+
+func (x *Silence) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Silence.ProtoReflect.Descriptor instead.
+func (*Silence) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Silence) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Silence) GetMatcher() *MessageMatcher {
+	if x != nil {
+		return x.Matcher
+	}
+	return nil
+}
+
+func (x *Silence) GetStartsAt() int64 {
+	if x != nil {
+		return x.StartsAt
+	}
+	return 0
+}
+
+func (x *Silence) GetEndsAt() int64 {
+	if x != nil {
+		return x.EndsAt
+	}
+	return 0
+}
+
+func (x *Silence) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Silence) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *Silence) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type CreateSilenceRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Silence *Silence               `protobuf:"bytes,1,opt,name=silence,proto3" json:"silence,omitempty"`
+	// used when silence.endsAt is not specified
+	DurationSeconds int64 `protobuf:"varint,2,opt,name=durationSeconds,proto3" json:"durationSeconds,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateSilenceRequest) Reset() {
+	*x = CreateSilenceRequest{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSilenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSilenceRequest) ProtoMessage() {}
+
+func (x *CreateSilenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSilenceRequest.ProtoReflect.Descriptor instead.
+func (*CreateSilenceRequest) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateSilenceRequest) GetSilence() *Silence {
+	if x != nil {
+		return x.Silence
+	}
+	return nil
+}
+
+func (x *CreateSilenceRequest) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+type CreateSilenceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Silence       *Silence               `protobuf:"bytes,1,opt,name=silence,proto3" json:"silence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSilenceResponse) Reset() {
+	*x = CreateSilenceResponse{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSilenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSilenceResponse) ProtoMessage() {}
+
+func (x *CreateSilenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSilenceResponse.ProtoReflect.Descriptor instead.
+func (*CreateSilenceResponse) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateSilenceResponse) GetSilence() *Silence {
+	if x != nil {
+		return x.Silence
+	}
+	return nil
+}
+
+type ListSilencesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	IncludeExpired bool                   `protobuf:"varint,1,opt,name=includeExpired,proto3" json:"includeExpired,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListSilencesRequest) Reset() {
+	*x = ListSilencesRequest{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSilencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSilencesRequest) ProtoMessage() {}
+
+func (x *ListSilencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSilencesRequest.ProtoReflect.Descriptor instead.
+func (*ListSilencesRequest) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListSilencesRequest) GetIncludeExpired() bool {
+	if x != nil {
+		return x.IncludeExpired
+	}
+	return false
+}
+
+type ListSilencesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Silences      []*Silence             `protobuf:"bytes,1,rep,name=silences,proto3" json:"silences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSilencesResponse) Reset() {
+	*x = ListSilencesResponse{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSilencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSilencesResponse) ProtoMessage() {}
+
+func (x *ListSilencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSilencesResponse.ProtoReflect.Descriptor instead.
+func (*ListSilencesResponse) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListSilencesResponse) GetSilences() []*Silence {
+	if x != nil {
+		return x.Silences
+	}
+	return nil
+}
+
+type ExpireSilenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpireSilenceRequest) Reset() {
+	*x = ExpireSilenceRequest{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpireSilenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpireSilenceRequest) ProtoMessage() {}
+
+func (x *ExpireSilenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpireSilenceRequest.ProtoReflect.Descriptor instead.
+func (*ExpireSilenceRequest) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ExpireSilenceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ExpireSilenceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Silence       *Silence               `protobuf:"bytes,1,opt,name=silence,proto3" json:"silence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpireSilenceResponse) Reset() {
+	*x = ExpireSilenceResponse{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpireSilenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpireSilenceResponse) ProtoMessage() {}
+
+func (x *ExpireSilenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpireSilenceResponse.ProtoReflect.Descriptor instead.
+func (*ExpireSilenceResponse) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ExpireSilenceResponse) GetSilence() *Silence {
+	if x != nil {
+		return x.Silence
+	}
+	return nil
+}
+
+// on-call page of MAJOR alarm. level is 0 based escalation level
+type Page struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Policy string                 `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	Level  int32                  `protobuf:"varint,3,opt,name=level,proto3" json:"level,omitempty"`
+	Oncall string                 `protobuf:"bytes,4,opt,name=oncall,proto3" json:"oncall,omitempty"`
+	// original alarm (MBusMessageBody json)
+	JsonString     string `protobuf:"bytes,5,opt,name=jsonString,proto3" json:"jsonString,omitempty"`
+	CreatedAt      int64  `protobuf:"varint,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	EscalatedAt    int64  `protobuf:"varint,7,opt,name=escalatedAt,proto3" json:"escalatedAt,omitempty"`
+	AcknowledgedBy string `protobuf:"bytes,8,opt,name=acknowledgedBy,proto3" json:"acknowledgedBy,omitempty"`
+	AcknowledgedAt int64  `protobuf:"varint,9,opt,name=acknowledgedAt,proto3" json:"acknowledgedAt,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Page) Reset() {
+	*x = Page{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Page) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Page) ProtoMessage() {}
+
+func (x *Page) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Page.ProtoReflect.Descriptor instead.
+func (*Page) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Page) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Page) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+func (x *Page) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Page) GetOncall() string {
+	if x != nil {
+		return x.Oncall
+	}
+	return ""
+}
+
+func (x *Page) GetJsonString() string {
+	if x != nil {
+		return x.JsonString
+	}
+	return ""
+}
+
+func (x *Page) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Page) GetEscalatedAt() int64 {
+	if x != nil {
+		return x.EscalatedAt
+	}
+	return 0
+}
+
+func (x *Page) GetAcknowledgedBy() string {
+	if x != nil {
+		return x.AcknowledgedBy
+	}
+	return ""
+}
+
+func (x *Page) GetAcknowledgedAt() int64 {
+	if x != nil {
+		return x.AcknowledgedAt
+	}
+	return 0
+}
+
+type ListPagesRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	IncludeAcknowledged bool                   `protobuf:"varint,1,opt,name=includeAcknowledged,proto3" json:"includeAcknowledged,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListPagesRequest) Reset() {
+	*x = ListPagesRequest{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPagesRequest) ProtoMessage() {}
+
+func (x *ListPagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPagesRequest.ProtoReflect.Descriptor instead.
+func (*ListPagesRequest) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListPagesRequest) GetIncludeAcknowledged() bool {
+	if x != nil {
+		return x.IncludeAcknowledged
+	}
+	return false
+}
+
+type ListPagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pages         []*Page                `protobuf:"bytes,1,rep,name=pages,proto3" json:"pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPagesResponse) Reset() {
+	*x = ListPagesResponse{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPagesResponse) ProtoMessage() {}
+
+func (x *ListPagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPagesResponse.ProtoReflect.Descriptor instead.
+func (*ListPagesResponse) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListPagesResponse) GetPages() []*Page {
+	if x != nil {
+		return x.Pages
+	}
+	return nil
+}
+
+type AcknowledgePageRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AcknowledgedBy string                 `protobuf:"bytes,2,opt,name=acknowledgedBy,proto3" json:"acknowledgedBy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AcknowledgePageRequest) Reset() {
+	*x = AcknowledgePageRequest{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcknowledgePageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcknowledgePageRequest) ProtoMessage() {}
+
+func (x *AcknowledgePageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcknowledgePageRequest.ProtoReflect.Descriptor instead.
+func (*AcknowledgePageRequest) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AcknowledgePageRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AcknowledgePageRequest) GetAcknowledgedBy() string {
+	if x != nil {
+		return x.AcknowledgedBy
+	}
+	return ""
+}
+
+type AcknowledgePageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          *Page                  `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcknowledgePageResponse) Reset() {
+	*x = AcknowledgePageResponse{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcknowledgePageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcknowledgePageResponse) ProtoMessage() {}
+
+func (x *AcknowledgePageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcknowledgePageResponse.ProtoReflect.Descriptor instead.
+func (*AcknowledgePageResponse) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AcknowledgePageResponse) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+// alarm lifecycle. state is one of firing, acknowledged, resolved, silenced
+type Alarm struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	State string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	// first message of the alarm (MBusMessageBody json)
+	JsonString    string   `protobuf:"bytes,3,opt,name=jsonString,proto3" json:"jsonString,omitempty"`
+	Count         int32    `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	FiredAt       int64    `protobuf:"varint,5,opt,name=firedAt,proto3" json:"firedAt,omitempty"`
+	LastAt        int64    `protobuf:"varint,6,opt,name=lastAt,proto3" json:"lastAt,omitempty"`
+	UpdatedAt     int64    `protobuf:"varint,7,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	UpdatedBy     string   `protobuf:"bytes,8,opt,name=updatedBy,proto3" json:"updatedBy,omitempty"`
+	Comment       string   `protobuf:"bytes,9,opt,name=comment,proto3" json:"comment,omitempty"`
+	Notified      []string `protobuf:"bytes,10,rep,name=notified,proto3" json:"notified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Alarm) Reset() {
+	*x = Alarm{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Alarm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alarm) ProtoMessage() {}
+
+func (x *Alarm) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alarm.ProtoReflect.Descriptor instead.
+func (*Alarm) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Alarm) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Alarm) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Alarm) GetJsonString() string {
+	if x != nil {
+		return x.JsonString
+	}
+	return ""
+}
+
+func (x *Alarm) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Alarm) GetFiredAt() int64 {
+	if x != nil {
+		return x.FiredAt
+	}
+	return 0
+}
+
+func (x *Alarm) GetLastAt() int64 {
+	if x != nil {
+		return x.LastAt
+	}
+	return 0
+}
+
+func (x *Alarm) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Alarm) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *Alarm) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *Alarm) GetNotified() []string {
+	if x != nil {
+		return x.Notified
+	}
+	return nil
+}
+
+type ListAlarmsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// every state if empty
+	States        []string `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlarmsRequest) Reset() {
+	*x = ListAlarmsRequest{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlarmsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlarmsRequest) ProtoMessage() {}
+
+func (x *ListAlarmsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlarmsRequest.ProtoReflect.Descriptor instead.
+func (*ListAlarmsRequest) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListAlarmsRequest) GetStates() []string {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+type ListAlarmsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alarms        []*Alarm               `protobuf:"bytes,1,rep,name=alarms,proto3" json:"alarms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlarmsResponse) Reset() {
+	*x = ListAlarmsResponse{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlarmsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlarmsResponse) ProtoMessage() {}
+
+func (x *ListAlarmsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlarmsResponse.ProtoReflect.Descriptor instead.
+func (*ListAlarmsResponse) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListAlarmsResponse) GetAlarms() []*Alarm {
+	if x != nil {
+		return x.Alarms
+	}
+	return nil
+}
+
+type ChangeAlarmRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UpdatedBy     string                 `protobuf:"bytes,2,opt,name=updatedBy,proto3" json:"updatedBy,omitempty"`
+	Comment       string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeAlarmRequest) Reset() {
+	*x = ChangeAlarmRequest{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeAlarmRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeAlarmRequest) ProtoMessage() {}
+
+func (x *ChangeAlarmRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeAlarmRequest.ProtoReflect.Descriptor instead.
+func (*ChangeAlarmRequest) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ChangeAlarmRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ChangeAlarmRequest) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *ChangeAlarmRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type ChangeAlarmResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alarm         *Alarm                 `protobuf:"bytes,1,opt,name=alarm,proto3" json:"alarm,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeAlarmResponse) Reset() {
+	*x = ChangeAlarmResponse{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeAlarmResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeAlarmResponse) ProtoMessage() {}
+
+func (x *ChangeAlarmResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeAlarmResponse.ProtoReflect.Descriptor instead.
+func (*ChangeAlarmResponse) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ChangeAlarmResponse) GetAlarm() *Alarm {
+	if x != nil {
+		return x.Alarm
+	}
+	return nil
+}
+
+// notification which failed after retries
+type DeadLetter struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Notifier string                 `protobuf:"bytes,2,opt,name=notifier,proto3" json:"notifier,omitempty"`
+	// undelivered message (MBusMessageBody json)
+	JsonString    string `protobuf:"bytes,3,opt,name=jsonString,proto3" json:"jsonString,omitempty"`
+	CreatedAt     int64  `protobuf:"varint,4,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	Attempts      int32  `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError     string `protobuf:"bytes,6,opt,name=lastError,proto3" json:"lastError,omitempty"`
+	LastAttemptAt int64  `protobuf:"varint,7,opt,name=lastAttemptAt,proto3" json:"lastAttemptAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeadLetter) Reset() {
+	*x = DeadLetter{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadLetter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetter) ProtoMessage() {}
+
+func (x *DeadLetter) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetter.ProtoReflect.Descriptor instead.
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeadLetter) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetNotifier() string {
+	if x != nil {
+		return x.Notifier
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetJsonString() string {
+	if x != nil {
+		return x.JsonString
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetLastAttemptAt() int64 {
+	if x != nil {
+		return x.LastAttemptAt
+	}
+	return 0
+}
+
+type ListDeadLettersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// every notifier if empty
+	Notifier      string `protobuf:"bytes,1,opt,name=notifier,proto3" json:"notifier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersRequest) Reset() {
+	*x = ListDeadLettersRequest{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersRequest) ProtoMessage() {}
+
+func (x *ListDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListDeadLettersRequest) GetNotifier() string {
+	if x != nil {
+		return x.Notifier
+	}
+	return ""
+}
+
+type ListDeadLettersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetters   []*DeadLetter          `protobuf:"bytes,1,rep,name=deadLetters,proto3" json:"deadLetters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersResponse) Reset() {
+	*x = ListDeadLettersResponse{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersResponse) ProtoMessage() {}
+
+func (x *ListDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListDeadLettersResponse) GetDeadLetters() []*DeadLetter {
+	if x != nil {
+		return x.DeadLetters
+	}
+	return nil
+}
+
+type GetDeadLetterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeadLetterRequest) Reset() {
+	*x = GetDeadLetterRequest{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeadLetterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeadLetterRequest) ProtoMessage() {}
+
+func (x *GetDeadLetterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeadLetterRequest.ProtoReflect.Descriptor instead.
+func (*GetDeadLetterRequest) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetDeadLetterRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetDeadLetterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetter    *DeadLetter            `protobuf:"bytes,1,opt,name=deadLetter,proto3" json:"deadLetter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeadLetterResponse) Reset() {
+	*x = GetDeadLetterResponse{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeadLetterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeadLetterResponse) ProtoMessage() {}
+
+func (x *GetDeadLetterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeadLetterResponse.ProtoReflect.Descriptor instead.
+func (*GetDeadLetterResponse) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetDeadLetterResponse) GetDeadLetter() *DeadLetter {
+	if x != nil {
+		return x.DeadLetter
+	}
+	return nil
+}
+
+// dead letter is removed from the store and sent again through outbox
+type RedeliverDeadLetterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeliverDeadLetterRequest) Reset() {
+	*x = RedeliverDeadLetterRequest{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeliverDeadLetterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeliverDeadLetterRequest) ProtoMessage() {}
+
+func (x *RedeliverDeadLetterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeliverDeadLetterRequest.ProtoReflect.Descriptor instead.
+func (*RedeliverDeadLetterRequest) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RedeliverDeadLetterRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RedeliverDeadLetterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetter    *DeadLetter            `protobuf:"bytes,1,opt,name=deadLetter,proto3" json:"deadLetter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeliverDeadLetterResponse) Reset() {
+	*x = RedeliverDeadLetterResponse{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeliverDeadLetterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeliverDeadLetterResponse) ProtoMessage() {}
+
+func (x *RedeliverDeadLetterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeliverDeadLetterResponse.ProtoReflect.Descriptor instead.
+func (*RedeliverDeadLetterResponse) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RedeliverDeadLetterResponse) GetDeadLetter() *DeadLetter {
+	if x != nil {
+		return x.DeadLetter
+	}
+	return nil
+}
+
+// removes dead letters of ids and notifier. ids or notifier is required unless all is set
+type PurgeDeadLettersRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Ids      []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	Notifier string                 `protobuf:"bytes,2,opt,name=notifier,proto3" json:"notifier,omitempty"`
+	// removes every dead letter when ids and notifier are empty
+	All           bool `protobuf:"varint,3,opt,name=all,proto3" json:"all,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeadLettersRequest) Reset() {
+	*x = PurgeDeadLettersRequest{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeadLettersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeadLettersRequest) ProtoMessage() {}
+
+func (x *PurgeDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*PurgeDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PurgeDeadLettersRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *PurgeDeadLettersRequest) GetNotifier() string {
+	if x != nil {
+		return x.Notifier
+	}
+	return ""
+}
+
+func (x *PurgeDeadLettersRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+type PurgeDeadLettersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Purged        int32                  `protobuf:"varint,1,opt,name=purged,proto3" json:"purged,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeadLettersResponse) Reset() {
+	*x = PurgeDeadLettersResponse{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeadLettersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeadLettersResponse) ProtoMessage() {}
+
+func (x *PurgeDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*PurgeDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PurgeDeadLettersResponse) GetPurged() int32 {
+	if x != nil {
+		return x.Purged
+	}
+	return 0
+}
+
+// live decisions of messages which match any of matchers. every message if empty
+// decisions are dropped for slow subscriber
+type SubscribeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matchers      []*MessageMatcher      `protobuf:"bytes,1,rep,name=matchers,proto3" json:"matchers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SubscribeRequest) GetMatchers() []*MessageMatcher {
+	if x != nil {
+		return x.Matchers
+	}
+	return nil
+}
+
+// accepted message and what saturn did with it
+type MessageDecision struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// accepted message (MBusMessageBody json)
+	JsonString string `protobuf:"bytes,1,opt,name=jsonString,proto3" json:"jsonString,omitempty"`
+	// notified, filtered, silenced, flapping, redundant, storm, restart, held, rejected
+	Result string `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	// silence which matched (silenced)
+	SilenceId string `protobuf:"bytes,3,opt,name=silenceId,proto3" json:"silenceId,omitempty"`
+	// notifiers which the message was routed to (notified)
+	Notified      []string `protobuf:"bytes,4,rep,name=notified,proto3" json:"notified,omitempty"`
+	DecidedAt     int64    `protobuf:"varint,5,opt,name=decidedAt,proto3" json:"decidedAt,omitempty"`
+	Id            string   `protobuf:"bytes,6,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageDecision) Reset() {
+	*x = MessageDecision{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageDecision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageDecision) ProtoMessage() {}
+
+func (x *MessageDecision) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageDecision.ProtoReflect.Descriptor instead.
+func (*MessageDecision) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *MessageDecision) GetJsonString() string {
+	if x != nil {
+		return x.JsonString
+	}
+	return ""
+}
+
+func (x *MessageDecision) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *MessageDecision) GetSilenceId() string {
+	if x != nil {
+		return x.SilenceId
+	}
+	return ""
+}
+
+func (x *MessageDecision) GetNotified() []string {
+	if x != nil {
+		return x.Notified
+	}
+	return nil
+}
+
+func (x *MessageDecision) GetDecidedAt() int64 {
+	if x != nil {
+		return x.DecidedAt
+	}
+	return 0
+}
+
+func (x *MessageDecision) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// history of accepted messages. time values are unix epoch milliseconds
+type QueryAlarmsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// retention days (history.retention.days) ago if 0
+	From int64 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	// now if 0
+	To int64 `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	// any of matchers. every message if empty
+	Matchers []*MessageMatcher `protobuf:"bytes,3,rep,name=matchers,proto3" json:"matchers,omitempty"`
+	// case insensitive text in message, host or process
+	Text string `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	// decision results (notified, filtered, silenced, ...). every result if empty
+	Results []string `protobuf:"bytes,5,rep,name=results,proto3" json:"results,omitempty"`
+	// 100 if 0 (max 1000)
+	PageSize int32 `protobuf:"varint,6,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// nextPageToken of previous response
+	PageToken     string `protobuf:"bytes,7,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAlarmsRequest) Reset() {
+	*x = QueryAlarmsRequest{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAlarmsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAlarmsRequest) ProtoMessage() {}
+
+func (x *QueryAlarmsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAlarmsRequest.ProtoReflect.Descriptor instead.
+func (*QueryAlarmsRequest) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *QueryAlarmsRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *QueryAlarmsRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *QueryAlarmsRequest) GetMatchers() []*MessageMatcher {
+	if x != nil {
+		return x.Matchers
+	}
+	return nil
+}
+
+func (x *QueryAlarmsRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *QueryAlarmsRequest) GetResults() []string {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *QueryAlarmsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *QueryAlarmsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// decisions in newest first order
+type QueryAlarmsResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Decisions []*MessageDecision     `protobuf:"bytes,1,rep,name=decisions,proto3" json:"decisions,omitempty"`
+	// empty if last page
+	NextPageToken string `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAlarmsResponse) Reset() {
+	*x = QueryAlarmsResponse{}
+	mi := &file_saturn_admin_v1_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAlarmsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAlarmsResponse) ProtoMessage() {}
+
+func (x *QueryAlarmsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_admin_v1_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAlarmsResponse.ProtoReflect.Descriptor instead.
+func (*QueryAlarmsResponse) Descriptor() ([]byte, []int) {
+	return file_saturn_admin_v1_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *QueryAlarmsResponse) GetDecisions() []*MessageDecision {
+	if x != nil {
+		return x.Decisions
+	}
+	return nil
+}
+
+func (x *QueryAlarmsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+var File_saturn_admin_v1_proto protoreflect.FileDescriptor
+
+const file_saturn_admin_v1_proto_rawDesc = "" +
+	"\n" +
+	"\x15saturn.admin.v1.proto\x12\x0fsaturn.admin.v1\"\xf0\x01\n" +
+	"\x0eMessageMatcher\x12\x18\n" +
+	"\aprofile\x18\x01 \x01(\tR\aprofile\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x12\n" +
+	"\x04host\x18\x03 \x01(\tR\x04host\x12\x18\n" +
+	"\aprocess\x18\x04 \x01(\tR\aprocess\x12\x1e\n" +
+	"\n" +
+	"alarmLevel\x18\x05 \x01(\tR\n" +
+	"alarmLevel\x12\x12\n" +
+	"\x04type\x18\x06 \x01(\tR\x04type\x12\x16\n" +
+	"\x06action\x18\a \x01(\tR\x06action\x12\x1a\n" +
+	"\bcategory\x18\b \x01(\tR\bcategory\x12\x18\n" +
+	"\amessage\x18\t \x01(\tR\amessage\"\xde\x01\n" +
+	"\aSilence\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\amatcher\x18\x02 \x01(\v2\x1f.saturn.admin.v1.MessageMatcherR\amatcher\x12\x1a\n" +
+	"\bstartsAt\x18\x03 \x01(\x03R\bstartsAt\x12\x16\n" +
+	"\x06endsAt\x18\x04 \x01(\x03R\x06endsAt\x12\x1c\n" +
+	"\tcreatedBy\x18\x05 \x01(\tR\tcreatedBy\x12\x18\n" +
+	"\acomment\x18\x06 \x01(\tR\acomment\x12\x1c\n" +
+	"\tcreatedAt\x18\a \x01(\x03R\tcreatedAt\"t\n" +
+	"\x14CreateSilenceRequest\x122\n" +
+	"\asilence\x18\x01 \x01(\v2\x18.saturn.admin.v1.SilenceR\asilence\x12(\n" +
+	"\x0fdurationSeconds\x18\x02 \x01(\x03R\x0fdurationSeconds\"K\n" +
+	"\x15CreateSilenceResponse\x122\n" +
+	"\asilence\x18\x01 \x01(\v2\x18.saturn.admin.v1.SilenceR\asilence\"=\n" +
+	"\x13ListSilencesRequest\x12&\n" +
+	"\x0eincludeExpired\x18\x01 \x01(\bR\x0eincludeExpired\"L\n" +
+	"\x14ListSilencesResponse\x124\n" +
+	"\bsilences\x18\x01 \x03(\v2\x18.saturn.admin.v1.SilenceR\bsilences\"&\n" +
+	"\x14ExpireSilenceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"K\n" +
+	"\x15ExpireSilenceResponse\x122\n" +
+	"\asilence\x18\x01 \x01(\v2\x18.saturn.admin.v1.SilenceR\asilence\"\x8c\x02\n" +
+	"\x04Page\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06policy\x18\x02 \x01(\tR\x06policy\x12\x14\n" +
+	"\x05level\x18\x03 \x01(\x05R\x05level\x12\x16\n" +
+	"\x06oncall\x18\x04 \x01(\tR\x06oncall\x12\x1e\n" +
+	"\n" +
+	"jsonString\x18\x05 \x01(\tR\n" +
+	"jsonString\x12\x1c\n" +
+	"\tcreatedAt\x18\x06 \x01(\x03R\tcreatedAt\x12 \n" +
+	"\vescalatedAt\x18\a \x01(\x03R\vescalatedAt\x12&\n" +
+	"\x0eacknowledgedBy\x18\b \x01(\tR\x0eacknowledgedBy\x12&\n" +
+	"\x0eacknowledgedAt\x18\t \x01(\x03R\x0eacknowledgedAt\"D\n" +
+	"\x10ListPagesRequest\x120\n" +
+	"\x13includeAcknowledged\x18\x01 \x01(\bR\x13includeAcknowledged\"@\n" +
+	"\x11ListPagesResponse\x12+\n" +
+	"\x05pages\x18\x01 \x03(\v2\x15.saturn.admin.v1.PageR\x05pages\"P\n" +
+	"\x16AcknowledgePageRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
+	"\x0eacknowledgedBy\x18\x02 \x01(\tR\x0eacknowledgedBy\"D\n" +
+	"\x17AcknowledgePageResponse\x12)\n" +
+	"\x04page\x18\x01 \x01(\v2\x15.saturn.admin.v1.PageR\x04page\"\x87\x02\n" +
+	"\x05Alarm\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x1e\n" +
+	"\n" +
+	"jsonString\x18\x03 \x01(\tR\n" +
+	"jsonString\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count\x12\x18\n" +
+	"\afiredAt\x18\x05 \x01(\x03R\afiredAt\x12\x16\n" +
+	"\x06lastAt\x18\x06 \x01(\x03R\x06lastAt\x12\x1c\n" +
+	"\tupdatedAt\x18\a \x01(\x03R\tupdatedAt\x12\x1c\n" +
+	"\tupdatedBy\x18\b \x01(\tR\tupdatedBy\x12\x18\n" +
+	"\acomment\x18\t \x01(\tR\acomment\x12\x1a\n" +
+	"\bnotified\x18\n" +
+	" \x03(\tR\bnotified\"+\n" +
+	"\x11ListAlarmsRequest\x12\x16\n" +
+	"\x06states\x18\x01 \x03(\tR\x06states\"D\n" +
+	"\x12ListAlarmsResponse\x12.\n" +
+	"\x06alarms\x18\x01 \x03(\v2\x16.saturn.admin.v1.AlarmR\x06alarms\"\\\n" +
+	"\x12ChangeAlarmRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\tupdatedBy\x18\x02 \x01(\tR\tupdatedBy\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\"C\n" +
+	"\x13ChangeAlarmResponse\x12,\n" +
+	"\x05alarm\x18\x01 \x01(\v2\x16.saturn.admin.v1.AlarmR\x05alarm\"\xd6\x01\n" +
+	"\n" +
+	"DeadLetter\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bnotifier\x18\x02 \x01(\tR\bnotifier\x12\x1e\n" +
+	"\n" +
+	"jsonString\x18\x03 \x01(\tR\n" +
+	"jsonString\x12\x1c\n" +
+	"\tcreatedAt\x18\x04 \x01(\x03R\tcreatedAt\x12\x1a\n" +
+	"\battempts\x18\x05 \x01(\x05R\battempts\x12\x1c\n" +
+	"\tlastError\x18\x06 \x01(\tR\tlastError\x12$\n" +
+	"\rlastAttemptAt\x18\a \x01(\x03R\rlastAttemptAt\"4\n" +
+	"\x16ListDeadLettersRequest\x12\x1a\n" +
+	"\bnotifier\x18\x01 \x01(\tR\bnotifier\"X\n" +
+	"\x17ListDeadLettersResponse\x12=\n" +
+	"\vdeadLetters\x18\x01 \x03(\v2\x1b.saturn.admin.v1.DeadLetterR\vdeadLetters\"&\n" +
+	"\x14GetDeadLetterRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"T\n" +
+	"\x15GetDeadLetterResponse\x12;\n" +
+	"\n" +
+	"deadLetter\x18\x01 \x01(\v2\x1b.saturn.admin.v1.DeadLetterR\n" +
+	"deadLetter\",\n" +
+	"\x1aRedeliverDeadLetterRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"Z\n" +
+	"\x1bRedeliverDeadLetterResponse\x12;\n" +
+	"\n" +
+	"deadLetter\x18\x01 \x01(\v2\x1b.saturn.admin.v1.DeadLetterR\n" +
+	"deadLetter\"Y\n" +
+	"\x17PurgeDeadLettersRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12\x1a\n" +
+	"\bnotifier\x18\x02 \x01(\tR\bnotifier\x12\x10\n" +
+	"\x03all\x18\x03 \x01(\bR\x03all\"2\n" +
+	"\x18PurgeDeadLettersResponse\x12\x16\n" +
+	"\x06purged\x18\x01 \x01(\x05R\x06purged\"O\n" +
+	"\x10SubscribeRequest\x12;\n" +
+	"\bmatchers\x18\x01 \x03(\v2\x1f.saturn.admin.v1.MessageMatcherR\bmatchers\"\xb1\x01\n" +
+	"\x0fMessageDecision\x12\x1e\n" +
+	"\n" +
+	"jsonString\x18\x01 \x01(\tR\n" +
+	"jsonString\x12\x16\n" +
+	"\x06result\x18\x02 \x01(\tR\x06result\x12\x1c\n" +
+	"\tsilenceId\x18\x03 \x01(\tR\tsilenceId\x12\x1a\n" +
+	"\bnotified\x18\x04 \x03(\tR\bnotified\x12\x1c\n" +
+	"\tdecidedAt\x18\x05 \x01(\x03R\tdecidedAt\x12\x0e\n" +
+	"\x02id\x18\x06 \x01(\tR\x02id\"\xdd\x01\n" +
+	"\x12QueryAlarmsRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\x03R\x02to\x12;\n" +
+	"\bmatchers\x18\x03 \x03(\v2\x1f.saturn.admin.v1.MessageMatcherR\bmatchers\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\x12\x18\n" +
+	"\aresults\x18\x05 \x03(\tR\aresults\x12\x1a\n" +
+	"\bpageSize\x18\x06 \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\a \x01(\tR\tpageToken\"{\n" +
+	"\x13QueryAlarmsResponse\x12>\n" +
+	"\tdecisions\x18\x01 \x03(\v2 .saturn.admin.v1.MessageDecisionR\tdecisions\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken2\xe7\n" +
+	"\n" +
+	"\x12SaturnAdminService\x12`\n" +
+	"\rCreateSilence\x12%.saturn.admin.v1.CreateSilenceRequest\x1a&.saturn.admin.v1.CreateSilenceResponse\"\x00\x12]\n" +
+	"\fListSilences\x12$.saturn.admin.v1.ListSilencesRequest\x1a%.saturn.admin.v1.ListSilencesResponse\"\x00\x12`\n" +
+	"\rExpireSilence\x12%.saturn.admin.v1.ExpireSilenceRequest\x1a&.saturn.admin.v1.ExpireSilenceResponse\"\x00\x12T\n" +
+	"\tListPages\x12!.saturn.admin.v1.ListPagesRequest\x1a\".saturn.admin.v1.ListPagesResponse\"\x00\x12f\n" +
+	"\x0fAcknowledgePage\x12'.saturn.admin.v1.AcknowledgePageRequest\x1a(.saturn.admin.v1.AcknowledgePageResponse\"\x00\x12W\n" +
+	"\n" +
+	"ListAlarms\x12\".saturn.admin.v1.ListAlarmsRequest\x1a#.saturn.admin.v1.ListAlarmsResponse\"\x00\x12_\n" +
+	"\x10AcknowledgeAlarm\x12#.saturn.admin.v1.ChangeAlarmRequest\x1a$.saturn.admin.v1.ChangeAlarmResponse\"\x00\x12[\n" +
+	"\fResolveAlarm\x12#.saturn.admin.v1.ChangeAlarmRequest\x1a$.saturn.admin.v1.ChangeAlarmResponse\"\x00\x12f\n" +
+	"\x0fListDeadLetters\x12'.saturn.admin.v1.ListDeadLettersRequest\x1a(.saturn.admin.v1.ListDeadLettersResponse\"\x00\x12`\n" +
+	"\rGetDeadLetter\x12%.saturn.admin.v1.GetDeadLetterRequest\x1a&.saturn.admin.v1.GetDeadLetterResponse\"\x00\x12r\n" +
+	"\x13RedeliverDeadLetter\x12+.saturn.admin.v1.RedeliverDeadLetterRequest\x1a,.saturn.admin.v1.RedeliverDeadLetterResponse\"\x00\x12i\n" +
+	"\x10PurgeDeadLetters\x12(.saturn.admin.v1.PurgeDeadLettersRequest\x1a).saturn.admin.v1.PurgeDeadLettersResponse\"\x00\x12T\n" +
+	"\tSubscribe\x12!.saturn.admin.v1.SubscribeRequest\x1a .saturn.admin.v1.MessageDecision\"\x000\x01\x12Z\n" +
+	"\vQueryAlarms\x12#.saturn.admin.v1.QueryAlarmsRequest\x1a$.saturn.admin.v1.QueryAlarmsResponse\"\x00B\x13Z\x11.;saturn_admin_v1b\x06proto3"
+
+var (
+	file_saturn_admin_v1_proto_rawDescOnce sync.Once
+	file_saturn_admin_v1_proto_rawDescData []byte
+)
+
+func file_saturn_admin_v1_proto_rawDescGZIP() []byte {
+	file_saturn_admin_v1_proto_rawDescOnce.Do(func() {
+		file_saturn_admin_v1_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_saturn_admin_v1_proto_rawDesc), len(file_saturn_admin_v1_proto_rawDesc)))
+	})
+	return file_saturn_admin_v1_proto_rawDescData
+}
+
+var file_saturn_admin_v1_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_saturn_admin_v1_proto_goTypes = []any{
+	(*MessageMatcher)(nil),              // 0: saturn.admin.v1.MessageMatcher
+	(*Silence)(nil),                     // 1: saturn.admin.v1.Silence
+	(*CreateSilenceRequest)(nil),        // 2: saturn.admin.v1.CreateSilenceRequest
+	(*CreateSilenceResponse)(nil),       // 3: saturn.admin.v1.CreateSilenceResponse
+	(*ListSilencesRequest)(nil),         // 4: saturn.admin.v1.ListSilencesRequest
+	(*ListSilencesResponse)(nil),        // 5: saturn.admin.v1.ListSilencesResponse
+	(*ExpireSilenceRequest)(nil),        // 6: saturn.admin.v1.ExpireSilenceRequest
+	(*ExpireSilenceResponse)(nil),       // 7: saturn.admin.v1.ExpireSilenceResponse
+	(*Page)(nil),                        // 8: saturn.admin.v1.Page
+	(*ListPagesRequest)(nil),            // 9: saturn.admin.v1.ListPagesRequest
+	(*ListPagesResponse)(nil),           // 10: saturn.admin.v1.ListPagesResponse
+	(*AcknowledgePageRequest)(nil),      // 11: saturn.admin.v1.AcknowledgePageRequest
+	(*AcknowledgePageResponse)(nil),     // 12: saturn.admin.v1.AcknowledgePageResponse
+	(*Alarm)(nil),                       // 13: saturn.admin.v1.Alarm
+	(*ListAlarmsRequest)(nil),           // 14: saturn.admin.v1.ListAlarmsRequest
+	(*ListAlarmsResponse)(nil),          // 15: saturn.admin.v1.ListAlarmsResponse
+	(*ChangeAlarmRequest)(nil),          // 16: saturn.admin.v1.ChangeAlarmRequest
+	(*ChangeAlarmResponse)(nil),         // 17: saturn.admin.v1.ChangeAlarmResponse
+	(*DeadLetter)(nil),                  // 18: saturn.admin.v1.DeadLetter
+	(*ListDeadLettersRequest)(nil),      // 19: saturn.admin.v1.ListDeadLettersRequest
+	(*ListDeadLettersResponse)(nil),     // 20: saturn.admin.v1.ListDeadLettersResponse
+	(*GetDeadLetterRequest)(nil),        // 21: saturn.admin.v1.GetDeadLetterRequest
+	(*GetDeadLetterResponse)(nil),       // 22: saturn.admin.v1.GetDeadLetterResponse
+	(*RedeliverDeadLetterRequest)(nil),  // 23: saturn.admin.v1.RedeliverDeadLetterRequest
+	(*RedeliverDeadLetterResponse)(nil), // 24: saturn.admin.v1.RedeliverDeadLetterResponse
+	(*PurgeDeadLettersRequest)(nil),     // 25: saturn.admin.v1.PurgeDeadLettersRequest
+	(*PurgeDeadLettersResponse)(nil),    // 26: saturn.admin.v1.PurgeDeadLettersResponse
+	(*SubscribeRequest)(nil),            // 27: saturn.admin.v1.SubscribeRequest
+	(*MessageDecision)(nil),             // 28: saturn.admin.v1.MessageDecision
+	(*QueryAlarmsRequest)(nil),          // 29: saturn.admin.v1.QueryAlarmsRequest
+	(*QueryAlarmsResponse)(nil),         // 30: saturn.admin.v1.QueryAlarmsResponse
+}
+var file_saturn_admin_v1_proto_depIdxs = []int32{
+	0,  // 0: saturn.admin.v1.Silence.matcher:type_name -> saturn.admin.v1.MessageMatcher
+	1,  // 1: saturn.admin.v1.CreateSilenceRequest.silence:type_name -> saturn.admin.v1.Silence
+	1,  // 2: saturn.admin.v1.CreateSilenceResponse.silence:type_name -> saturn.admin.v1.Silence
+	1,  // 3: saturn.admin.v1.ListSilencesResponse.silences:type_name -> saturn.admin.v1.Silence
+	1,  // 4: saturn.admin.v1.ExpireSilenceResponse.silence:type_name -> saturn.admin.v1.Silence
+	8,  // 5: saturn.admin.v1.ListPagesResponse.pages:type_name -> saturn.admin.v1.Page
+	8,  // 6: saturn.admin.v1.AcknowledgePageResponse.page:type_name -> saturn.admin.v1.Page
+	13, // 7: saturn.admin.v1.ListAlarmsResponse.alarms:type_name -> saturn.admin.v1.Alarm
+	13, // 8: saturn.admin.v1.ChangeAlarmResponse.alarm:type_name -> saturn.admin.v1.Alarm
+	18, // 9: saturn.admin.v1.ListDeadLettersResponse.deadLetters:type_name -> saturn.admin.v1.DeadLetter
+	18, // 10: saturn.admin.v1.GetDeadLetterResponse.deadLetter:type_name -> saturn.admin.v1.DeadLetter
+	18, // 11: saturn.admin.v1.RedeliverDeadLetterResponse.deadLetter:type_name -> saturn.admin.v1.DeadLetter
+	0,  // 12: saturn.admin.v1.SubscribeRequest.matchers:type_name -> saturn.admin.v1.MessageMatcher
+	0,  // 13: saturn.admin.v1.QueryAlarmsRequest.matchers:type_name -> saturn.admin.v1.MessageMatcher
+	28, // 14: saturn.admin.v1.QueryAlarmsResponse.decisions:type_name -> saturn.admin.v1.MessageDecision
+	2,  // 15: saturn.admin.v1.SaturnAdminService.CreateSilence:input_type -> saturn.admin.v1.CreateSilenceRequest
+	4,  // 16: saturn.admin.v1.SaturnAdminService.ListSilences:input_type -> saturn.admin.v1.ListSilencesRequest
+	6,  // 17: saturn.admin.v1.SaturnAdminService.ExpireSilence:input_type -> saturn.admin.v1.ExpireSilenceRequest
+	9,  // 18: saturn.admin.v1.SaturnAdminService.ListPages:input_type -> saturn.admin.v1.ListPagesRequest
+	11, // 19: saturn.admin.v1.SaturnAdminService.AcknowledgePage:input_type -> saturn.admin.v1.AcknowledgePageRequest
+	14, // 20: saturn.admin.v1.SaturnAdminService.ListAlarms:input_type -> saturn.admin.v1.ListAlarmsRequest
+	16, // 21: saturn.admin.v1.SaturnAdminService.AcknowledgeAlarm:input_type -> saturn.admin.v1.ChangeAlarmRequest
+	16, // 22: saturn.admin.v1.SaturnAdminService.ResolveAlarm:input_type -> saturn.admin.v1.ChangeAlarmRequest
+	19, // 23: saturn.admin.v1.SaturnAdminService.ListDeadLetters:input_type -> saturn.admin.v1.ListDeadLettersRequest
+	21, // 24: saturn.admin.v1.SaturnAdminService.GetDeadLetter:input_type -> saturn.admin.v1.GetDeadLetterRequest
+	23, // 25: saturn.admin.v1.SaturnAdminService.RedeliverDeadLetter:input_type -> saturn.admin.v1.RedeliverDeadLetterRequest
+	25, // 26: saturn.admin.v1.SaturnAdminService.PurgeDeadLetters:input_type -> saturn.admin.v1.PurgeDeadLettersRequest
+	27, // 27: saturn.admin.v1.SaturnAdminService.Subscribe:input_type -> saturn.admin.v1.SubscribeRequest
+	29, // 28: saturn.admin.v1.SaturnAdminService.QueryAlarms:input_type -> saturn.admin.v1.QueryAlarmsRequest
+	3,  // 29: saturn.admin.v1.SaturnAdminService.CreateSilence:output_type -> saturn.admin.v1.CreateSilenceResponse
+	5,  // 30: saturn.admin.v1.SaturnAdminService.ListSilences:output_type -> saturn.admin.v1.ListSilencesResponse
+	7,  // 31: saturn.admin.v1.SaturnAdminService.ExpireSilence:output_type -> saturn.admin.v1.ExpireSilenceResponse
+	10, // 32: saturn.admin.v1.SaturnAdminService.ListPages:output_type -> saturn.admin.v1.ListPagesResponse
+	12, // 33: saturn.admin.v1.SaturnAdminService.AcknowledgePage:output_type -> saturn.admin.v1.AcknowledgePageResponse
+	15, // 34: saturn.admin.v1.SaturnAdminService.ListAlarms:output_type -> saturn.admin.v1.ListAlarmsResponse
+	17, // 35: saturn.admin.v1.SaturnAdminService.AcknowledgeAlarm:output_type -> saturn.admin.v1.ChangeAlarmResponse
+	17, // 36: saturn.admin.v1.SaturnAdminService.ResolveAlarm:output_type -> saturn.admin.v1.ChangeAlarmResponse
+	20, // 37: saturn.admin.v1.SaturnAdminService.ListDeadLetters:output_type -> saturn.admin.v1.ListDeadLettersResponse
+	22, // 38: saturn.admin.v1.SaturnAdminService.GetDeadLetter:output_type -> saturn.admin.v1.GetDeadLetterResponse
+	24, // 39: saturn.admin.v1.SaturnAdminService.RedeliverDeadLetter:output_type -> saturn.admin.v1.RedeliverDeadLetterResponse
+	26, // 40: saturn.admin.v1.SaturnAdminService.PurgeDeadLetters:output_type -> saturn.admin.v1.PurgeDeadLettersResponse
+	28, // 41: saturn.admin.v1.SaturnAdminService.Subscribe:output_type -> saturn.admin.v1.MessageDecision
+	30, // 42: saturn.admin.v1.SaturnAdminService.QueryAlarms:output_type -> saturn.admin.v1.QueryAlarmsResponse
+	29, // [29:43] is the sub-list for method output_type
+	15, // [15:29] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_saturn_admin_v1_proto_init() }
+func file_saturn_admin_v1_proto_init() {
+	if File_saturn_admin_v1_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_saturn_admin_v1_proto_rawDesc), len(file_saturn_admin_v1_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   31,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_saturn_admin_v1_proto_goTypes,
+		DependencyIndexes: file_saturn_admin_v1_proto_depIdxs,
+		MessageInfos:      file_saturn_admin_v1_proto_msgTypes,
+	}.Build()
+	File_saturn_admin_v1_proto = out.File
+	file_saturn_admin_v1_proto_goTypes = nil
+	file_saturn_admin_v1_proto_depIdxs = nil
+}
@@ -0,0 +1,619 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: saturn.admin.v1.proto
+
+package saturn_admin_v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SaturnAdminService_CreateSilence_FullMethodName       = "/saturn.admin.v1.SaturnAdminService/CreateSilence"
+	SaturnAdminService_ListSilences_FullMethodName        = "/saturn.admin.v1.SaturnAdminService/ListSilences"
+	SaturnAdminService_ExpireSilence_FullMethodName       = "/saturn.admin.v1.SaturnAdminService/ExpireSilence"
+	SaturnAdminService_ListPages_FullMethodName           = "/saturn.admin.v1.SaturnAdminService/ListPages"
+	SaturnAdminService_AcknowledgePage_FullMethodName     = "/saturn.admin.v1.SaturnAdminService/AcknowledgePage"
+	SaturnAdminService_ListAlarms_FullMethodName          = "/saturn.admin.v1.SaturnAdminService/ListAlarms"
+	SaturnAdminService_AcknowledgeAlarm_FullMethodName    = "/saturn.admin.v1.SaturnAdminService/AcknowledgeAlarm"
+	SaturnAdminService_ResolveAlarm_FullMethodName        = "/saturn.admin.v1.SaturnAdminService/ResolveAlarm"
+	SaturnAdminService_ListDeadLetters_FullMethodName     = "/saturn.admin.v1.SaturnAdminService/ListDeadLetters"
+	SaturnAdminService_GetDeadLetter_FullMethodName       = "/saturn.admin.v1.SaturnAdminService/GetDeadLetter"
+	SaturnAdminService_RedeliverDeadLetter_FullMethodName = "/saturn.admin.v1.SaturnAdminService/RedeliverDeadLetter"
+	SaturnAdminService_PurgeDeadLetters_FullMethodName    = "/saturn.admin.v1.SaturnAdminService/PurgeDeadLetters"
+	SaturnAdminService_Subscribe_FullMethodName           = "/saturn.admin.v1.SaturnAdminService/Subscribe"
+	SaturnAdminService_QueryAlarms_FullMethodName         = "/saturn.admin.v1.SaturnAdminService/QueryAlarms"
+)
+
+// SaturnAdminServiceClient is the client API for SaturnAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SaturnAdminServiceClient interface {
+	CreateSilence(ctx context.Context, in *CreateSilenceRequest, opts ...grpc.CallOption) (*CreateSilenceResponse, error)
+	ListSilences(ctx context.Context, in *ListSilencesRequest, opts ...grpc.CallOption) (*ListSilencesResponse, error)
+	ExpireSilence(ctx context.Context, in *ExpireSilenceRequest, opts ...grpc.CallOption) (*ExpireSilenceResponse, error)
+	ListPages(ctx context.Context, in *ListPagesRequest, opts ...grpc.CallOption) (*ListPagesResponse, error)
+	AcknowledgePage(ctx context.Context, in *AcknowledgePageRequest, opts ...grpc.CallOption) (*AcknowledgePageResponse, error)
+	ListAlarms(ctx context.Context, in *ListAlarmsRequest, opts ...grpc.CallOption) (*ListAlarmsResponse, error)
+	AcknowledgeAlarm(ctx context.Context, in *ChangeAlarmRequest, opts ...grpc.CallOption) (*ChangeAlarmResponse, error)
+	ResolveAlarm(ctx context.Context, in *ChangeAlarmRequest, opts ...grpc.CallOption) (*ChangeAlarmResponse, error)
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error)
+	GetDeadLetter(ctx context.Context, in *GetDeadLetterRequest, opts ...grpc.CallOption) (*GetDeadLetterResponse, error)
+	RedeliverDeadLetter(ctx context.Context, in *RedeliverDeadLetterRequest, opts ...grpc.CallOption) (*RedeliverDeadLetterResponse, error)
+	PurgeDeadLetters(ctx context.Context, in *PurgeDeadLettersRequest, opts ...grpc.CallOption) (*PurgeDeadLettersResponse, error)
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MessageDecision], error)
+	QueryAlarms(ctx context.Context, in *QueryAlarmsRequest, opts ...grpc.CallOption) (*QueryAlarmsResponse, error)
+}
+
+type saturnAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSaturnAdminServiceClient(cc grpc.ClientConnInterface) SaturnAdminServiceClient {
+	return &saturnAdminServiceClient{cc}
+}
+
+func (c *saturnAdminServiceClient) CreateSilence(ctx context.Context, in *CreateSilenceRequest, opts ...grpc.CallOption) (*CreateSilenceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSilenceResponse)
+	err := c.cc.Invoke(ctx, SaturnAdminService_CreateSilence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *saturnAdminServiceClient) ListSilences(ctx context.Context, in *ListSilencesRequest, opts ...grpc.CallOption) (*ListSilencesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSilencesResponse)
+	err := c.cc.Invoke(ctx, SaturnAdminService_ListSilences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *saturnAdminServiceClient) ExpireSilence(ctx context.Context, in *ExpireSilenceRequest, opts ...grpc.CallOption) (*ExpireSilenceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExpireSilenceResponse)
+	err := c.cc.Invoke(ctx, SaturnAdminService_ExpireSilence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *saturnAdminServiceClient) ListPages(ctx context.Context, in *ListPagesRequest, opts ...grpc.CallOption) (*ListPagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPagesResponse)
+	err := c.cc.Invoke(ctx, SaturnAdminService_ListPages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *saturnAdminServiceClient) AcknowledgePage(ctx context.Context, in *AcknowledgePageRequest, opts ...grpc.CallOption) (*AcknowledgePageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcknowledgePageResponse)
+	err := c.cc.Invoke(ctx, SaturnAdminService_AcknowledgePage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *saturnAdminServiceClient) ListAlarms(ctx context.Context, in *ListAlarmsRequest, opts ...grpc.CallOption) (*ListAlarmsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAlarmsResponse)
+	err := c.cc.Invoke(ctx, SaturnAdminService_ListAlarms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *saturnAdminServiceClient) AcknowledgeAlarm(ctx context.Context, in *ChangeAlarmRequest, opts ...grpc.CallOption) (*ChangeAlarmResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeAlarmResponse)
+	err := c.cc.Invoke(ctx, SaturnAdminService_AcknowledgeAlarm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *saturnAdminServiceClient) ResolveAlarm(ctx context.Context, in *ChangeAlarmRequest, opts ...grpc.CallOption) (*ChangeAlarmResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeAlarmResponse)
+	err := c.cc.Invoke(ctx, SaturnAdminService_ResolveAlarm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *saturnAdminServiceClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeadLettersResponse)
+	err := c.cc.Invoke(ctx, SaturnAdminService_ListDeadLetters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *saturnAdminServiceClient) GetDeadLetter(ctx context.Context, in *GetDeadLetterRequest, opts ...grpc.CallOption) (*GetDeadLetterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDeadLetterResponse)
+	err := c.cc.Invoke(ctx, SaturnAdminService_GetDeadLetter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *saturnAdminServiceClient) RedeliverDeadLetter(ctx context.Context, in *RedeliverDeadLetterRequest, opts ...grpc.CallOption) (*RedeliverDeadLetterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RedeliverDeadLetterResponse)
+	err := c.cc.Invoke(ctx, SaturnAdminService_RedeliverDeadLetter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *saturnAdminServiceClient) PurgeDeadLetters(ctx context.Context, in *PurgeDeadLettersRequest, opts ...grpc.CallOption) (*PurgeDeadLettersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeDeadLettersResponse)
+	err := c.cc.Invoke(ctx, SaturnAdminService_PurgeDeadLetters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *saturnAdminServiceClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MessageDecision], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SaturnAdminService_ServiceDesc.Streams[0], SaturnAdminService_Subscribe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, MessageDecision]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SaturnAdminService_SubscribeClient = grpc.ServerStreamingClient[MessageDecision]
+
+func (c *saturnAdminServiceClient) QueryAlarms(ctx context.Context, in *QueryAlarmsRequest, opts ...grpc.CallOption) (*QueryAlarmsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryAlarmsResponse)
+	err := c.cc.Invoke(ctx, SaturnAdminService_QueryAlarms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SaturnAdminServiceServer is the server API for SaturnAdminService service.
+// All implementations must embed UnimplementedSaturnAdminServiceServer
+// for forward compatibility.
+type SaturnAdminServiceServer interface {
+	CreateSilence(context.Context, *CreateSilenceRequest) (*CreateSilenceResponse, error)
+	ListSilences(context.Context, *ListSilencesRequest) (*ListSilencesResponse, error)
+	ExpireSilence(context.Context, *ExpireSilenceRequest) (*ExpireSilenceResponse, error)
+	ListPages(context.Context, *ListPagesRequest) (*ListPagesResponse, error)
+	AcknowledgePage(context.Context, *AcknowledgePageRequest) (*AcknowledgePageResponse, error)
+	ListAlarms(context.Context, *ListAlarmsRequest) (*ListAlarmsResponse, error)
+	AcknowledgeAlarm(context.Context, *ChangeAlarmRequest) (*ChangeAlarmResponse, error)
+	ResolveAlarm(context.Context, *ChangeAlarmRequest) (*ChangeAlarmResponse, error)
+	ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error)
+	GetDeadLetter(context.Context, *GetDeadLetterRequest) (*GetDeadLetterResponse, error)
+	RedeliverDeadLetter(context.Context, *RedeliverDeadLetterRequest) (*RedeliverDeadLetterResponse, error)
+	PurgeDeadLetters(context.Context, *PurgeDeadLettersRequest) (*PurgeDeadLettersResponse, error)
+	Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[MessageDecision]) error
+	QueryAlarms(context.Context, *QueryAlarmsRequest) (*QueryAlarmsResponse, error)
+	mustEmbedUnimplementedSaturnAdminServiceServer()
+}
+
+// UnimplementedSaturnAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSaturnAdminServiceServer struct{}
+
+func (UnimplementedSaturnAdminServiceServer) CreateSilence(context.Context, *CreateSilenceRequest) (*CreateSilenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSilence not implemented")
+}
+func (UnimplementedSaturnAdminServiceServer) ListSilences(context.Context, *ListSilencesRequest) (*ListSilencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSilences not implemented")
+}
+func (UnimplementedSaturnAdminServiceServer) ExpireSilence(context.Context, *ExpireSilenceRequest) (*ExpireSilenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExpireSilence not implemented")
+}
+func (UnimplementedSaturnAdminServiceServer) ListPages(context.Context, *ListPagesRequest) (*ListPagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPages not implemented")
+}
+func (UnimplementedSaturnAdminServiceServer) AcknowledgePage(context.Context, *AcknowledgePageRequest) (*AcknowledgePageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcknowledgePage not implemented")
+}
+func (UnimplementedSaturnAdminServiceServer) ListAlarms(context.Context, *ListAlarmsRequest) (*ListAlarmsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAlarms not implemented")
+}
+func (UnimplementedSaturnAdminServiceServer) AcknowledgeAlarm(context.Context, *ChangeAlarmRequest) (*ChangeAlarmResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcknowledgeAlarm not implemented")
+}
+func (UnimplementedSaturnAdminServiceServer) ResolveAlarm(context.Context, *ChangeAlarmRequest) (*ChangeAlarmResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveAlarm not implemented")
+}
+func (UnimplementedSaturnAdminServiceServer) ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadLetters not implemented")
+}
+func (UnimplementedSaturnAdminServiceServer) GetDeadLetter(context.Context, *GetDeadLetterRequest) (*GetDeadLetterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeadLetter not implemented")
+}
+func (UnimplementedSaturnAdminServiceServer) RedeliverDeadLetter(context.Context, *RedeliverDeadLetterRequest) (*RedeliverDeadLetterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeliverDeadLetter not implemented")
+}
+func (UnimplementedSaturnAdminServiceServer) PurgeDeadLetters(context.Context, *PurgeDeadLettersRequest) (*PurgeDeadLettersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeDeadLetters not implemented")
+}
+func (UnimplementedSaturnAdminServiceServer) Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[MessageDecision]) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedSaturnAdminServiceServer) QueryAlarms(context.Context, *QueryAlarmsRequest) (*QueryAlarmsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAlarms not implemented")
+}
+func (UnimplementedSaturnAdminServiceServer) mustEmbedUnimplementedSaturnAdminServiceServer() {}
+func (UnimplementedSaturnAdminServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeSaturnAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SaturnAdminServiceServer will
+// result in compilation errors.
+type UnsafeSaturnAdminServiceServer interface {
+	mustEmbedUnimplementedSaturnAdminServiceServer()
+}
+
+func RegisterSaturnAdminServiceServer(s grpc.ServiceRegistrar, srv SaturnAdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSaturnAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SaturnAdminService_ServiceDesc, srv)
+}
+
+func _SaturnAdminService_CreateSilence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSilenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaturnAdminServiceServer).CreateSilence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SaturnAdminService_CreateSilence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaturnAdminServiceServer).CreateSilence(ctx, req.(*CreateSilenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SaturnAdminService_ListSilences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSilencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaturnAdminServiceServer).ListSilences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SaturnAdminService_ListSilences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaturnAdminServiceServer).ListSilences(ctx, req.(*ListSilencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SaturnAdminService_ExpireSilence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExpireSilenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaturnAdminServiceServer).ExpireSilence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SaturnAdminService_ExpireSilence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaturnAdminServiceServer).ExpireSilence(ctx, req.(*ExpireSilenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SaturnAdminService_ListPages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaturnAdminServiceServer).ListPages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SaturnAdminService_ListPages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaturnAdminServiceServer).ListPages(ctx, req.(*ListPagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SaturnAdminService_AcknowledgePage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcknowledgePageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaturnAdminServiceServer).AcknowledgePage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SaturnAdminService_AcknowledgePage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaturnAdminServiceServer).AcknowledgePage(ctx, req.(*AcknowledgePageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SaturnAdminService_ListAlarms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAlarmsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaturnAdminServiceServer).ListAlarms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SaturnAdminService_ListAlarms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaturnAdminServiceServer).ListAlarms(ctx, req.(*ListAlarmsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SaturnAdminService_AcknowledgeAlarm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeAlarmRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaturnAdminServiceServer).AcknowledgeAlarm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SaturnAdminService_AcknowledgeAlarm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaturnAdminServiceServer).AcknowledgeAlarm(ctx, req.(*ChangeAlarmRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SaturnAdminService_ResolveAlarm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeAlarmRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaturnAdminServiceServer).ResolveAlarm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SaturnAdminService_ResolveAlarm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaturnAdminServiceServer).ResolveAlarm(ctx, req.(*ChangeAlarmRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SaturnAdminService_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaturnAdminServiceServer).ListDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SaturnAdminService_ListDeadLetters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaturnAdminServiceServer).ListDeadLetters(ctx, req.(*ListDeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SaturnAdminService_GetDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeadLetterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaturnAdminServiceServer).GetDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SaturnAdminService_GetDeadLetter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaturnAdminServiceServer).GetDeadLetter(ctx, req.(*GetDeadLetterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SaturnAdminService_RedeliverDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeliverDeadLetterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaturnAdminServiceServer).RedeliverDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SaturnAdminService_RedeliverDeadLetter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaturnAdminServiceServer).RedeliverDeadLetter(ctx, req.(*RedeliverDeadLetterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SaturnAdminService_PurgeDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeDeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaturnAdminServiceServer).PurgeDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SaturnAdminService_PurgeDeadLetters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaturnAdminServiceServer).PurgeDeadLetters(ctx, req.(*PurgeDeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SaturnAdminService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SaturnAdminServiceServer).Subscribe(m, &grpc.GenericServerStream[SubscribeRequest, MessageDecision]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SaturnAdminService_SubscribeServer = grpc.ServerStreamingServer[MessageDecision]
+
+func _SaturnAdminService_QueryAlarms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAlarmsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaturnAdminServiceServer).QueryAlarms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SaturnAdminService_QueryAlarms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaturnAdminServiceServer).QueryAlarms(ctx, req.(*QueryAlarmsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SaturnAdminService_ServiceDesc is the grpc.ServiceDesc for SaturnAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SaturnAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "saturn.admin.v1.SaturnAdminService",
+	HandlerType: (*SaturnAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSilence",
+			Handler:    _SaturnAdminService_CreateSilence_Handler,
+		},
+		{
+			MethodName: "ListSilences",
+			Handler:    _SaturnAdminService_ListSilences_Handler,
+		},
+		{
+			MethodName: "ExpireSilence",
+			Handler:    _SaturnAdminService_ExpireSilence_Handler,
+		},
+		{
+			MethodName: "ListPages",
+			Handler:    _SaturnAdminService_ListPages_Handler,
+		},
+		{
+			MethodName: "AcknowledgePage",
+			Handler:    _SaturnAdminService_AcknowledgePage_Handler,
+		},
+		{
+			MethodName: "ListAlarms",
+			Handler:    _SaturnAdminService_ListAlarms_Handler,
+		},
+		{
+			MethodName: "AcknowledgeAlarm",
+			Handler:    _SaturnAdminService_AcknowledgeAlarm_Handler,
+		},
+		{
+			MethodName: "ResolveAlarm",
+			Handler:    _SaturnAdminService_ResolveAlarm_Handler,
+		},
+		{
+			MethodName: "ListDeadLetters",
+			Handler:    _SaturnAdminService_ListDeadLetters_Handler,
+		},
+		{
+			MethodName: "GetDeadLetter",
+			Handler:    _SaturnAdminService_GetDeadLetter_Handler,
+		},
+		{
+			MethodName: "RedeliverDeadLetter",
+			Handler:    _SaturnAdminService_RedeliverDeadLetter_Handler,
+		},
+		{
+			MethodName: "PurgeDeadLetters",
+			Handler:    _SaturnAdminService_PurgeDeadLetters_Handler,
+		},
+		{
+			MethodName: "QueryAlarms",
+			Handler:    _SaturnAdminService_QueryAlarms_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _SaturnAdminService_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "saturn.admin.v1.proto",
+}
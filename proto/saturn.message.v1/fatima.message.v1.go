@@ -0,0 +1,54 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 19. 오후 10:20
+ */
+
+package saturn_message_v1
+
+import (
+	_ "embed"
+
+	fatima_message_v1 "github.com/fatima-go/fatima-core/builder/fatima.message.v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// FatimaMessageService (fatima.message.v1_grpc.pb.go) is generated from fatima.message.v1.proto into this package.
+// messages of fatima.message.v1 are generated in fatima-core and batch messages are wire compatible copies
+// in saturn.message.v1.proto (see compile_guide.txt)
+type (
+	SendFatimaMessageRequest  = fatima_message_v1.SendFatimaMessageRequest
+	SendFatimaMessageResponse = fatima_message_v1.SendFatimaMessageResponse
+)
+
+// descriptorSet is fatima.message.v1.proto and saturn.message.v1.proto of this repository
+//
+//go:embed saturn.message.v1.binpb
+var descriptorSet []byte
+
+// NewFiles returns descriptors of fatima.message.v1.proto and saturn.message.v1.proto of this repository.
+// fatima.message.v1.proto registered in protoregistry.GlobalFiles by fatima-core has SendFatimaMessage only
+func NewFiles() (*protoregistry.Files, error) {
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(descriptorSet, set); err != nil {
+		return nil, err
+	}
+	return protodesc.NewFiles(set)
+}
@@ -0,0 +1,193 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: fatima.message.v1.proto
+
+package saturn_message_v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FatimaMessageService_SendFatimaMessage_FullMethodName    = "/fatima.message.v1.FatimaMessageService/SendFatimaMessage"
+	FatimaMessageService_SendFatimaMessages_FullMethodName   = "/fatima.message.v1.FatimaMessageService/SendFatimaMessages"
+	FatimaMessageService_StreamFatimaMessages_FullMethodName = "/fatima.message.v1.FatimaMessageService/StreamFatimaMessages"
+)
+
+// FatimaMessageServiceClient is the client API for FatimaMessageService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FatimaMessageServiceClient interface {
+	SendFatimaMessage(ctx context.Context, in *SendFatimaMessageRequest, opts ...grpc.CallOption) (*SendFatimaMessageResponse, error)
+	// batch and client-streaming ingest. each message has its own result
+	SendFatimaMessages(ctx context.Context, in *SendFatimaMessagesRequest, opts ...grpc.CallOption) (*SendFatimaMessagesResponse, error)
+	StreamFatimaMessages(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[SendFatimaMessageRequest, SendFatimaMessagesResponse], error)
+}
+
+type fatimaMessageServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFatimaMessageServiceClient(cc grpc.ClientConnInterface) FatimaMessageServiceClient {
+	return &fatimaMessageServiceClient{cc}
+}
+
+func (c *fatimaMessageServiceClient) SendFatimaMessage(ctx context.Context, in *SendFatimaMessageRequest, opts ...grpc.CallOption) (*SendFatimaMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendFatimaMessageResponse)
+	err := c.cc.Invoke(ctx, FatimaMessageService_SendFatimaMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fatimaMessageServiceClient) SendFatimaMessages(ctx context.Context, in *SendFatimaMessagesRequest, opts ...grpc.CallOption) (*SendFatimaMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendFatimaMessagesResponse)
+	err := c.cc.Invoke(ctx, FatimaMessageService_SendFatimaMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fatimaMessageServiceClient) StreamFatimaMessages(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[SendFatimaMessageRequest, SendFatimaMessagesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FatimaMessageService_ServiceDesc.Streams[0], FatimaMessageService_StreamFatimaMessages_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SendFatimaMessageRequest, SendFatimaMessagesResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FatimaMessageService_StreamFatimaMessagesClient = grpc.ClientStreamingClient[SendFatimaMessageRequest, SendFatimaMessagesResponse]
+
+// FatimaMessageServiceServer is the server API for FatimaMessageService service.
+// All implementations must embed UnimplementedFatimaMessageServiceServer
+// for forward compatibility.
+type FatimaMessageServiceServer interface {
+	SendFatimaMessage(context.Context, *SendFatimaMessageRequest) (*SendFatimaMessageResponse, error)
+	// batch and client-streaming ingest. each message has its own result
+	SendFatimaMessages(context.Context, *SendFatimaMessagesRequest) (*SendFatimaMessagesResponse, error)
+	StreamFatimaMessages(grpc.ClientStreamingServer[SendFatimaMessageRequest, SendFatimaMessagesResponse]) error
+	mustEmbedUnimplementedFatimaMessageServiceServer()
+}
+
+// UnimplementedFatimaMessageServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFatimaMessageServiceServer struct{}
+
+func (UnimplementedFatimaMessageServiceServer) SendFatimaMessage(context.Context, *SendFatimaMessageRequest) (*SendFatimaMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendFatimaMessage not implemented")
+}
+func (UnimplementedFatimaMessageServiceServer) SendFatimaMessages(context.Context, *SendFatimaMessagesRequest) (*SendFatimaMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendFatimaMessages not implemented")
+}
+func (UnimplementedFatimaMessageServiceServer) StreamFatimaMessages(grpc.ClientStreamingServer[SendFatimaMessageRequest, SendFatimaMessagesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamFatimaMessages not implemented")
+}
+func (UnimplementedFatimaMessageServiceServer) mustEmbedUnimplementedFatimaMessageServiceServer() {}
+func (UnimplementedFatimaMessageServiceServer) testEmbeddedByValue()                              {}
+
+// UnsafeFatimaMessageServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FatimaMessageServiceServer will
+// result in compilation errors.
+type UnsafeFatimaMessageServiceServer interface {
+	mustEmbedUnimplementedFatimaMessageServiceServer()
+}
+
+func RegisterFatimaMessageServiceServer(s grpc.ServiceRegistrar, srv FatimaMessageServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFatimaMessageServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FatimaMessageService_ServiceDesc, srv)
+}
+
+func _FatimaMessageService_SendFatimaMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendFatimaMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FatimaMessageServiceServer).SendFatimaMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FatimaMessageService_SendFatimaMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FatimaMessageServiceServer).SendFatimaMessage(ctx, req.(*SendFatimaMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FatimaMessageService_SendFatimaMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendFatimaMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FatimaMessageServiceServer).SendFatimaMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FatimaMessageService_SendFatimaMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FatimaMessageServiceServer).SendFatimaMessages(ctx, req.(*SendFatimaMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FatimaMessageService_StreamFatimaMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FatimaMessageServiceServer).StreamFatimaMessages(&grpc.GenericServerStream[SendFatimaMessageRequest, SendFatimaMessagesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FatimaMessageService_StreamFatimaMessagesServer = grpc.ClientStreamingServer[SendFatimaMessageRequest, SendFatimaMessagesResponse]
+
+// FatimaMessageService_ServiceDesc is the grpc.ServiceDesc for FatimaMessageService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FatimaMessageService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "fatima.message.v1.FatimaMessageService",
+	HandlerType: (*FatimaMessageServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SendFatimaMessage",
+			Handler:    _FatimaMessageService_SendFatimaMessage_Handler,
+		},
+		{
+			MethodName: "SendFatimaMessages",
+			Handler:    _FatimaMessageService_SendFatimaMessages_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamFatimaMessages",
+			Handler:       _FatimaMessageService_StreamFatimaMessages_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "fatima.message.v1.proto",
+}
@@ -0,0 +1,1040 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: saturn.message.v1.proto
+
+package saturn_message_v1
+
+import (
+	fatima_message_v1 "github.com/fatima-go/fatima-core/builder/fatima.message.v1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type NotifyType int32
+
+const (
+	NotifyType_NOTIFY_TYPE_UNSPECIFIED NotifyType = 0
+	NotifyType_NOTIFY_TYPE_ALARM       NotifyType = 1
+	NotifyType_NOTIFY_TYPE_EVENT       NotifyType = 2
+)
+
+// Enum value maps for NotifyType.
+var (
+	NotifyType_name = map[int32]string{
+		0: "NOTIFY_TYPE_UNSPECIFIED",
+		1: "NOTIFY_TYPE_ALARM",
+		2: "NOTIFY_TYPE_EVENT",
+	}
+	NotifyType_value = map[string]int32{
+		"NOTIFY_TYPE_UNSPECIFIED": 0,
+		"NOTIFY_TYPE_ALARM":       1,
+		"NOTIFY_TYPE_EVENT":       2,
+	}
+)
+
+func (x NotifyType) Enum() *NotifyType {
+	p := new(NotifyType)
+	*p = x
+	return p
+}
+
+func (x NotifyType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NotifyType) Descriptor() protoreflect.EnumDescriptor {
+	return file_saturn_message_v1_proto_enumTypes[0].Descriptor()
+}
+
+func (NotifyType) Type() protoreflect.EnumType {
+	return &file_saturn_message_v1_proto_enumTypes[0]
+}
+
+func (x NotifyType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NotifyType.Descriptor instead.
+func (NotifyType) EnumDescriptor() ([]byte, []int) {
+	return file_saturn_message_v1_proto_rawDescGZIP(), []int{0}
+}
+
+type AlarmLevel int32
+
+const (
+	AlarmLevel_ALARM_LEVEL_UNSPECIFIED AlarmLevel = 0
+	AlarmLevel_ALARM_LEVEL_WARN        AlarmLevel = 1
+	AlarmLevel_ALARM_LEVEL_MINOR       AlarmLevel = 2
+	AlarmLevel_ALARM_LEVEL_MAJOR       AlarmLevel = 3
+)
+
+// Enum value maps for AlarmLevel.
+var (
+	AlarmLevel_name = map[int32]string{
+		0: "ALARM_LEVEL_UNSPECIFIED",
+		1: "ALARM_LEVEL_WARN",
+		2: "ALARM_LEVEL_MINOR",
+		3: "ALARM_LEVEL_MAJOR",
+	}
+	AlarmLevel_value = map[string]int32{
+		"ALARM_LEVEL_UNSPECIFIED": 0,
+		"ALARM_LEVEL_WARN":        1,
+		"ALARM_LEVEL_MINOR":       2,
+		"ALARM_LEVEL_MAJOR":       3,
+	}
+)
+
+func (x AlarmLevel) Enum() *AlarmLevel {
+	p := new(AlarmLevel)
+	*p = x
+	return p
+}
+
+func (x AlarmLevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AlarmLevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_saturn_message_v1_proto_enumTypes[1].Descriptor()
+}
+
+func (AlarmLevel) Type() protoreflect.EnumType {
+	return &file_saturn_message_v1_proto_enumTypes[1]
+}
+
+func (x AlarmLevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AlarmLevel.Descriptor instead.
+func (AlarmLevel) EnumDescriptor() ([]byte, []int) {
+	return file_saturn_message_v1_proto_rawDescGZIP(), []int{1}
+}
+
+type Action int32
+
+const (
+	Action_ACTION_UNSPECIFIED      Action = 0
+	Action_ACTION_PROCESS_STARTUP  Action = 1
+	Action_ACTION_PROCESS_SHUTDOWN Action = 2
+)
+
+// Enum value maps for Action.
+var (
+	Action_name = map[int32]string{
+		0: "ACTION_UNSPECIFIED",
+		1: "ACTION_PROCESS_STARTUP",
+		2: "ACTION_PROCESS_SHUTDOWN",
+	}
+	Action_value = map[string]int32{
+		"ACTION_UNSPECIFIED":      0,
+		"ACTION_PROCESS_STARTUP":  1,
+		"ACTION_PROCESS_SHUTDOWN": 2,
+	}
+)
+
+func (x Action) Enum() *Action {
+	p := new(Action)
+	*p = x
+	return p
+}
+
+func (x Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_saturn_message_v1_proto_enumTypes[2].Descriptor()
+}
+
+func (Action) Type() protoreflect.EnumType {
+	return &file_saturn_message_v1_proto_enumTypes[2]
+}
+
+func (x Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Action.Descriptor instead.
+func (Action) EnumDescriptor() ([]byte, []int) {
+	return file_saturn_message_v1_proto_rawDescGZIP(), []int{2}
+}
+
+// SendFatimaMessagesRequest and SendFatimaMessagesResponse are wire compatible copies of fatima.message.v1 messages.
+// go code of fatima.message.v1 is generated in fatima-core. saturn serves batch rpcs of FatimaMessageService
+// with these messages until fatima-core is regenerated with them. keep fields the same as fatima.message.v1.proto
+type SendFatimaMessagesRequest struct {
+	state         protoimpl.MessageState                        `protogen:"open.v1"`
+	Messages      []*fatima_message_v1.SendFatimaMessageRequest `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendFatimaMessagesRequest) Reset() {
+	*x = SendFatimaMessagesRequest{}
+	mi := &file_saturn_message_v1_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendFatimaMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendFatimaMessagesRequest) ProtoMessage() {}
+
+func (x *SendFatimaMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_message_v1_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendFatimaMessagesRequest.ProtoReflect.Descriptor instead.
+func (*SendFatimaMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_saturn_message_v1_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SendFatimaMessagesRequest) GetMessages() []*fatima_message_v1.SendFatimaMessageRequest {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+// results are in the order of messages
+type SendFatimaMessagesResponse struct {
+	state         protoimpl.MessageState                         `protogen:"open.v1"`
+	Results       []*fatima_message_v1.SendFatimaMessageResponse `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Accepted      int32                                          `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Rejected      int32                                          `protobuf:"varint,3,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendFatimaMessagesResponse) Reset() {
+	*x = SendFatimaMessagesResponse{}
+	mi := &file_saturn_message_v1_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendFatimaMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendFatimaMessagesResponse) ProtoMessage() {}
+
+func (x *SendFatimaMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_message_v1_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendFatimaMessagesResponse.ProtoReflect.Descriptor instead.
+func (*SendFatimaMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_saturn_message_v1_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SendFatimaMessagesResponse) GetResults() []*fatima_message_v1.SendFatimaMessageResponse {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SendFatimaMessagesResponse) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *SendFatimaMessagesResponse) GetRejected() int32 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+// typed message or json string (fallback, same as SendFatimaMessage)
+type SendMessageRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*SendMessageRequest_Message
+	//	*SendMessageRequest_JsonString
+	Payload       isSendMessageRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendMessageRequest) Reset() {
+	*x = SendMessageRequest{}
+	mi := &file_saturn_message_v1_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMessageRequest) ProtoMessage() {}
+
+func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_message_v1_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMessageRequest.ProtoReflect.Descriptor instead.
+func (*SendMessageRequest) Descriptor() ([]byte, []int) {
+	return file_saturn_message_v1_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SendMessageRequest) GetPayload() isSendMessageRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *SendMessageRequest) GetMessage() *FatimaMessage {
+	if x != nil {
+		if x, ok := x.Payload.(*SendMessageRequest_Message); ok {
+			return x.Message
+		}
+	}
+	return nil
+}
+
+func (x *SendMessageRequest) GetJsonString() string {
+	if x != nil {
+		if x, ok := x.Payload.(*SendMessageRequest_JsonString); ok {
+			return x.JsonString
+		}
+	}
+	return ""
+}
+
+type isSendMessageRequest_Payload interface {
+	isSendMessageRequest_Payload()
+}
+
+type SendMessageRequest_Message struct {
+	Message *FatimaMessage `protobuf:"bytes,1,opt,name=message,proto3,oneof"`
+}
+
+type SendMessageRequest_JsonString struct {
+	JsonString string `protobuf:"bytes,2,opt,name=jsonString,proto3,oneof"`
+}
+
+func (*SendMessageRequest_Message) isSendMessageRequest_Payload() {}
+
+func (*SendMessageRequest_JsonString) isSendMessageRequest_Payload() {}
+
+// typed fatima message which mirrors json message (header, body)
+type FatimaMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *FatimaMessageHeader   `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Body          *FatimaMessageBody     `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FatimaMessage) Reset() {
+	*x = FatimaMessage{}
+	mi := &file_saturn_message_v1_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FatimaMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FatimaMessage) ProtoMessage() {}
+
+func (x *FatimaMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_message_v1_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FatimaMessage.ProtoReflect.Descriptor instead.
+func (*FatimaMessage) Descriptor() ([]byte, []int) {
+	return file_saturn_message_v1_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FatimaMessage) GetHeader() *FatimaMessageHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *FatimaMessage) GetBody() *FatimaMessageBody {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type FatimaMessageHeader struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// fatima application code (1) if 0
+	ApplicationCode int32 `protobuf:"varint,1,opt,name=applicationCode,proto3" json:"applicationCode,omitempty"`
+	// notify (20) if 0. measure (10) is ignored
+	Logic         int32 `protobuf:"varint,2,opt,name=logic,proto3" json:"logic,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FatimaMessageHeader) Reset() {
+	*x = FatimaMessageHeader{}
+	mi := &file_saturn_message_v1_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FatimaMessageHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FatimaMessageHeader) ProtoMessage() {}
+
+func (x *FatimaMessageHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_message_v1_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FatimaMessageHeader.ProtoReflect.Descriptor instead.
+func (*FatimaMessageHeader) Descriptor() ([]byte, []int) {
+	return file_saturn_message_v1_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FatimaMessageHeader) GetApplicationCode() int32 {
+	if x != nil {
+		return x.ApplicationCode
+	}
+	return 0
+}
+
+func (x *FatimaMessageHeader) GetLogic() int32 {
+	if x != nil {
+		return x.Logic
+	}
+	return 0
+}
+
+type FatimaMessageBody struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// unix epoch milliseconds. received time if 0
+	EventTime      int64          `protobuf:"varint,1,opt,name=eventTime,proto3" json:"eventTime,omitempty"`
+	PackageGroup   string         `protobuf:"bytes,2,opt,name=packageGroup,proto3" json:"packageGroup,omitempty"`
+	PackageHost    string         `protobuf:"bytes,3,opt,name=packageHost,proto3" json:"packageHost,omitempty"`
+	PackageName    string         `protobuf:"bytes,4,opt,name=packageName,proto3" json:"packageName,omitempty"`
+	PackageProcess string         `protobuf:"bytes,5,opt,name=packageProcess,proto3" json:"packageProcess,omitempty"`
+	PackageProfile string         `protobuf:"bytes,6,opt,name=packageProfile,proto3" json:"packageProfile,omitempty"`
+	Content        *NotifyContent `protobuf:"bytes,7,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FatimaMessageBody) Reset() {
+	*x = FatimaMessageBody{}
+	mi := &file_saturn_message_v1_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FatimaMessageBody) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FatimaMessageBody) ProtoMessage() {}
+
+func (x *FatimaMessageBody) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_message_v1_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FatimaMessageBody.ProtoReflect.Descriptor instead.
+func (*FatimaMessageBody) Descriptor() ([]byte, []int) {
+	return file_saturn_message_v1_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FatimaMessageBody) GetEventTime() int64 {
+	if x != nil {
+		return x.EventTime
+	}
+	return 0
+}
+
+func (x *FatimaMessageBody) GetPackageGroup() string {
+	if x != nil {
+		return x.PackageGroup
+	}
+	return ""
+}
+
+func (x *FatimaMessageBody) GetPackageHost() string {
+	if x != nil {
+		return x.PackageHost
+	}
+	return ""
+}
+
+func (x *FatimaMessageBody) GetPackageName() string {
+	if x != nil {
+		return x.PackageName
+	}
+	return ""
+}
+
+func (x *FatimaMessageBody) GetPackageProcess() string {
+	if x != nil {
+		return x.PackageProcess
+	}
+	return ""
+}
+
+func (x *FatimaMessageBody) GetPackageProfile() string {
+	if x != nil {
+		return x.PackageProfile
+	}
+	return ""
+}
+
+func (x *FatimaMessageBody) GetContent() *NotifyContent {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+// content of notify ("message" of json message)
+type NotifyContent struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Type       NotifyType             `protobuf:"varint,1,opt,name=type,proto3,enum=saturn.message.v1.NotifyType" json:"type,omitempty"`
+	AlarmLevel AlarmLevel             `protobuf:"varint,2,opt,name=alarmLevel,proto3,enum=saturn.message.v1.AlarmLevel" json:"alarmLevel,omitempty"`
+	Action     Action                 `protobuf:"varint,3,opt,name=action,proto3,enum=saturn.message.v1.Action" json:"action,omitempty"`
+	Category   string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	Message    string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// yyyy-MM-dd HH:mm:ss
+	Timestamp string `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	From      string `protobuf:"bytes,7,opt,name=from,proto3" json:"from,omitempty"`
+	Initiator string `protobuf:"bytes,8,opt,name=initiator,proto3" json:"initiator,omitempty"`
+	// build information of the process (usually with ACTION_PROCESS_STARTUP)
+	Deployment *Deployment `protobuf:"bytes,9,opt,name=deployment,proto3" json:"deployment,omitempty"`
+	// arguments of event message
+	Params []string `protobuf:"bytes,10,rep,name=params,proto3" json:"params,omitempty"`
+	// additional fields. keys of fields above and keys added by saturn (e.g. alarm_id) are rejected
+	Extra         map[string]string `protobuf:"bytes,11,rep,name=extra,proto3" json:"extra,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotifyContent) Reset() {
+	*x = NotifyContent{}
+	mi := &file_saturn_message_v1_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotifyContent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifyContent) ProtoMessage() {}
+
+func (x *NotifyContent) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_message_v1_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifyContent.ProtoReflect.Descriptor instead.
+func (*NotifyContent) Descriptor() ([]byte, []int) {
+	return file_saturn_message_v1_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *NotifyContent) GetType() NotifyType {
+	if x != nil {
+		return x.Type
+	}
+	return NotifyType_NOTIFY_TYPE_UNSPECIFIED
+}
+
+func (x *NotifyContent) GetAlarmLevel() AlarmLevel {
+	if x != nil {
+		return x.AlarmLevel
+	}
+	return AlarmLevel_ALARM_LEVEL_UNSPECIFIED
+}
+
+func (x *NotifyContent) GetAction() Action {
+	if x != nil {
+		return x.Action
+	}
+	return Action_ACTION_UNSPECIFIED
+}
+
+func (x *NotifyContent) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *NotifyContent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *NotifyContent) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *NotifyContent) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *NotifyContent) GetInitiator() string {
+	if x != nil {
+		return x.Initiator
+	}
+	return ""
+}
+
+func (x *NotifyContent) GetDeployment() *Deployment {
+	if x != nil {
+		return x.Deployment
+	}
+	return nil
+}
+
+func (x *NotifyContent) GetParams() []string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *NotifyContent) GetExtra() map[string]string {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
+type Deployment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Process       string                 `protobuf:"bytes,1,opt,name=process,proto3" json:"process,omitempty"`
+	ProcessType   string                 `protobuf:"bytes,2,opt,name=processType,proto3" json:"processType,omitempty"`
+	Build         *DeploymentBuild       `protobuf:"bytes,3,opt,name=build,proto3" json:"build,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Deployment) Reset() {
+	*x = Deployment{}
+	mi := &file_saturn_message_v1_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Deployment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Deployment) ProtoMessage() {}
+
+func (x *Deployment) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_message_v1_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Deployment.ProtoReflect.Descriptor instead.
+func (*Deployment) Descriptor() ([]byte, []int) {
+	return file_saturn_message_v1_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Deployment) GetProcess() string {
+	if x != nil {
+		return x.Process
+	}
+	return ""
+}
+
+func (x *Deployment) GetProcessType() string {
+	if x != nil {
+		return x.ProcessType
+	}
+	return ""
+}
+
+func (x *Deployment) GetBuild() *DeploymentBuild {
+	if x != nil {
+		return x.Build
+	}
+	return nil
+}
+
+type DeploymentBuild struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Git           *DeploymentBuildGit    `protobuf:"bytes,1,opt,name=git,proto3" json:"git,omitempty"`
+	Time          string                 `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	User          string                 `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeploymentBuild) Reset() {
+	*x = DeploymentBuild{}
+	mi := &file_saturn_message_v1_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeploymentBuild) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeploymentBuild) ProtoMessage() {}
+
+func (x *DeploymentBuild) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_message_v1_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeploymentBuild.ProtoReflect.Descriptor instead.
+func (*DeploymentBuild) Descriptor() ([]byte, []int) {
+	return file_saturn_message_v1_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeploymentBuild) GetGit() *DeploymentBuildGit {
+	if x != nil {
+		return x.Git
+	}
+	return nil
+}
+
+func (x *DeploymentBuild) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *DeploymentBuild) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+type DeploymentBuildGit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Branch        string                 `protobuf:"bytes,1,opt,name=branch,proto3" json:"branch,omitempty"`
+	Commit        string                 `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeploymentBuildGit) Reset() {
+	*x = DeploymentBuildGit{}
+	mi := &file_saturn_message_v1_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeploymentBuildGit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeploymentBuildGit) ProtoMessage() {}
+
+func (x *DeploymentBuildGit) ProtoReflect() protoreflect.Message {
+	mi := &file_saturn_message_v1_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeploymentBuildGit.ProtoReflect.Descriptor instead.
+func (*DeploymentBuildGit) Descriptor() ([]byte, []int) {
+	return file_saturn_message_v1_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeploymentBuildGit) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *DeploymentBuildGit) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *DeploymentBuildGit) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_saturn_message_v1_proto protoreflect.FileDescriptor
+
+const file_saturn_message_v1_proto_rawDesc = "" +
+	"\n" +
+	"\x17saturn.message.v1.proto\x12\x11saturn.message.v1\x1a\x17fatima.message.v1.proto\"d\n" +
+	"\x19SendFatimaMessagesRequest\x12G\n" +
+	"\bmessages\x18\x01 \x03(\v2+.fatima.message.v1.SendFatimaMessageRequestR\bmessages\"\x9c\x01\n" +
+	"\x1aSendFatimaMessagesResponse\x12F\n" +
+	"\aresults\x18\x01 \x03(\v2,.fatima.message.v1.SendFatimaMessageResponseR\aresults\x12\x1a\n" +
+	"\baccepted\x18\x02 \x01(\x05R\baccepted\x12\x1a\n" +
+	"\brejected\x18\x03 \x01(\x05R\brejected\"\x7f\n" +
+	"\x12SendMessageRequest\x12<\n" +
+	"\amessage\x18\x01 \x01(\v2 .saturn.message.v1.FatimaMessageH\x00R\amessage\x12 \n" +
+	"\n" +
+	"jsonString\x18\x02 \x01(\tH\x00R\n" +
+	"jsonStringB\t\n" +
+	"\apayload\"\x89\x01\n" +
+	"\rFatimaMessage\x12>\n" +
+	"\x06header\x18\x01 \x01(\v2&.saturn.message.v1.FatimaMessageHeaderR\x06header\x128\n" +
+	"\x04body\x18\x02 \x01(\v2$.saturn.message.v1.FatimaMessageBodyR\x04body\"U\n" +
+	"\x13FatimaMessageHeader\x12(\n" +
+	"\x0fapplicationCode\x18\x01 \x01(\x05R\x0fapplicationCode\x12\x14\n" +
+	"\x05logic\x18\x02 \x01(\x05R\x05logic\"\xa5\x02\n" +
+	"\x11FatimaMessageBody\x12\x1c\n" +
+	"\teventTime\x18\x01 \x01(\x03R\teventTime\x12\"\n" +
+	"\fpackageGroup\x18\x02 \x01(\tR\fpackageGroup\x12 \n" +
+	"\vpackageHost\x18\x03 \x01(\tR\vpackageHost\x12 \n" +
+	"\vpackageName\x18\x04 \x01(\tR\vpackageName\x12&\n" +
+	"\x0epackageProcess\x18\x05 \x01(\tR\x0epackageProcess\x12&\n" +
+	"\x0epackageProfile\x18\x06 \x01(\tR\x0epackageProfile\x12:\n" +
+	"\acontent\x18\a \x01(\v2 .saturn.message.v1.NotifyContentR\acontent\"\x8e\x04\n" +
+	"\rNotifyContent\x121\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1d.saturn.message.v1.NotifyTypeR\x04type\x12=\n" +
+	"\n" +
+	"alarmLevel\x18\x02 \x01(\x0e2\x1d.saturn.message.v1.AlarmLevelR\n" +
+	"alarmLevel\x121\n" +
+	"\x06action\x18\x03 \x01(\x0e2\x19.saturn.message.v1.ActionR\x06action\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12\x1c\n" +
+	"\ttimestamp\x18\x06 \x01(\tR\ttimestamp\x12\x12\n" +
+	"\x04from\x18\a \x01(\tR\x04from\x12\x1c\n" +
+	"\tinitiator\x18\b \x01(\tR\tinitiator\x12=\n" +
+	"\n" +
+	"deployment\x18\t \x01(\v2\x1d.saturn.message.v1.DeploymentR\n" +
+	"deployment\x12\x16\n" +
+	"\x06params\x18\n" +
+	" \x03(\tR\x06params\x12A\n" +
+	"\x05extra\x18\v \x03(\v2+.saturn.message.v1.NotifyContent.ExtraEntryR\x05extra\x1a8\n" +
+	"\n" +
+	"ExtraEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x82\x01\n" +
+	"\n" +
+	"Deployment\x12\x18\n" +
+	"\aprocess\x18\x01 \x01(\tR\aprocess\x12 \n" +
+	"\vprocessType\x18\x02 \x01(\tR\vprocessType\x128\n" +
+	"\x05build\x18\x03 \x01(\v2\".saturn.message.v1.DeploymentBuildR\x05build\"r\n" +
+	"\x0fDeploymentBuild\x127\n" +
+	"\x03git\x18\x01 \x01(\v2%.saturn.message.v1.DeploymentBuildGitR\x03git\x12\x12\n" +
+	"\x04time\x18\x02 \x01(\tR\x04time\x12\x12\n" +
+	"\x04user\x18\x03 \x01(\tR\x04user\"^\n" +
+	"\x12DeploymentBuildGit\x12\x16\n" +
+	"\x06branch\x18\x01 \x01(\tR\x06branch\x12\x16\n" +
+	"\x06commit\x18\x02 \x01(\tR\x06commit\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage*W\n" +
+	"\n" +
+	"NotifyType\x12\x1b\n" +
+	"\x17NOTIFY_TYPE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11NOTIFY_TYPE_ALARM\x10\x01\x12\x15\n" +
+	"\x11NOTIFY_TYPE_EVENT\x10\x02*m\n" +
+	"\n" +
+	"AlarmLevel\x12\x1b\n" +
+	"\x17ALARM_LEVEL_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10ALARM_LEVEL_WARN\x10\x01\x12\x15\n" +
+	"\x11ALARM_LEVEL_MINOR\x10\x02\x12\x15\n" +
+	"\x11ALARM_LEVEL_MAJOR\x10\x03*Y\n" +
+	"\x06Action\x12\x16\n" +
+	"\x12ACTION_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16ACTION_PROCESS_STARTUP\x10\x01\x12\x1b\n" +
+	"\x17ACTION_PROCESS_SHUTDOWN\x10\x022|\n" +
+	"\x14SaturnMessageService\x12d\n" +
+	"\vSendMessage\x12%.saturn.message.v1.SendMessageRequest\x1a,.fatima.message.v1.SendFatimaMessageResponse\"\x00B\x15Z\x13.;saturn_message_v1b\x06proto3"
+
+var (
+	file_saturn_message_v1_proto_rawDescOnce sync.Once
+	file_saturn_message_v1_proto_rawDescData []byte
+)
+
+func file_saturn_message_v1_proto_rawDescGZIP() []byte {
+	file_saturn_message_v1_proto_rawDescOnce.Do(func() {
+		file_saturn_message_v1_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_saturn_message_v1_proto_rawDesc), len(file_saturn_message_v1_proto_rawDesc)))
+	})
+	return file_saturn_message_v1_proto_rawDescData
+}
+
+var file_saturn_message_v1_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_saturn_message_v1_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_saturn_message_v1_proto_goTypes = []any{
+	(NotifyType)(0),                    // 0: saturn.message.v1.NotifyType
+	(AlarmLevel)(0),                    // 1: saturn.message.v1.AlarmLevel
+	(Action)(0),                        // 2: saturn.message.v1.Action
+	(*SendFatimaMessagesRequest)(nil),  // 3: saturn.message.v1.SendFatimaMessagesRequest
+	(*SendFatimaMessagesResponse)(nil), // 4: saturn.message.v1.SendFatimaMessagesResponse
+	(*SendMessageRequest)(nil),         // 5: saturn.message.v1.SendMessageRequest
+	(*FatimaMessage)(nil),              // 6: saturn.message.v1.FatimaMessage
+	(*FatimaMessageHeader)(nil),        // 7: saturn.message.v1.FatimaMessageHeader
+	(*FatimaMessageBody)(nil),          // 8: saturn.message.v1.FatimaMessageBody
+	(*NotifyContent)(nil),              // 9: saturn.message.v1.NotifyContent
+	(*Deployment)(nil),                 // 10: saturn.message.v1.Deployment
+	(*DeploymentBuild)(nil),            // 11: saturn.message.v1.DeploymentBuild
+	(*DeploymentBuildGit)(nil),         // 12: saturn.message.v1.DeploymentBuildGit
+	nil,                                // 13: saturn.message.v1.NotifyContent.ExtraEntry
+	(*fatima_message_v1.SendFatimaMessageRequest)(nil),  // 14: fatima.message.v1.SendFatimaMessageRequest
+	(*fatima_message_v1.SendFatimaMessageResponse)(nil), // 15: fatima.message.v1.SendFatimaMessageResponse
+}
+var file_saturn_message_v1_proto_depIdxs = []int32{
+	14, // 0: saturn.message.v1.SendFatimaMessagesRequest.messages:type_name -> fatima.message.v1.SendFatimaMessageRequest
+	15, // 1: saturn.message.v1.SendFatimaMessagesResponse.results:type_name -> fatima.message.v1.SendFatimaMessageResponse
+	6,  // 2: saturn.message.v1.SendMessageRequest.message:type_name -> saturn.message.v1.FatimaMessage
+	7,  // 3: saturn.message.v1.FatimaMessage.header:type_name -> saturn.message.v1.FatimaMessageHeader
+	8,  // 4: saturn.message.v1.FatimaMessage.body:type_name -> saturn.message.v1.FatimaMessageBody
+	9,  // 5: saturn.message.v1.FatimaMessageBody.content:type_name -> saturn.message.v1.NotifyContent
+	0,  // 6: saturn.message.v1.NotifyContent.type:type_name -> saturn.message.v1.NotifyType
+	1,  // 7: saturn.message.v1.NotifyContent.alarmLevel:type_name -> saturn.message.v1.AlarmLevel
+	2,  // 8: saturn.message.v1.NotifyContent.action:type_name -> saturn.message.v1.Action
+	10, // 9: saturn.message.v1.NotifyContent.deployment:type_name -> saturn.message.v1.Deployment
+	13, // 10: saturn.message.v1.NotifyContent.extra:type_name -> saturn.message.v1.NotifyContent.ExtraEntry
+	11, // 11: saturn.message.v1.Deployment.build:type_name -> saturn.message.v1.DeploymentBuild
+	12, // 12: saturn.message.v1.DeploymentBuild.git:type_name -> saturn.message.v1.DeploymentBuildGit
+	5,  // 13: saturn.message.v1.SaturnMessageService.SendMessage:input_type -> saturn.message.v1.SendMessageRequest
+	15, // 14: saturn.message.v1.SaturnMessageService.SendMessage:output_type -> fatima.message.v1.SendFatimaMessageResponse
+	14, // [14:15] is the sub-list for method output_type
+	13, // [13:14] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_saturn_message_v1_proto_init() }
+func file_saturn_message_v1_proto_init() {
+	if File_saturn_message_v1_proto != nil {
+		return
+	}
+	file_saturn_message_v1_proto_msgTypes[2].OneofWrappers = []any{
+		(*SendMessageRequest_Message)(nil),
+		(*SendMessageRequest_JsonString)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_saturn_message_v1_proto_rawDesc), len(file_saturn_message_v1_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_saturn_message_v1_proto_goTypes,
+		DependencyIndexes: file_saturn_message_v1_proto_depIdxs,
+		EnumInfos:         file_saturn_message_v1_proto_enumTypes,
+		MessageInfos:      file_saturn_message_v1_proto_msgTypes,
+	}.Build()
+	File_saturn_message_v1_proto = out.File
+	file_saturn_message_v1_proto_goTypes = nil
+	file_saturn_message_v1_proto_depIdxs = nil
+}
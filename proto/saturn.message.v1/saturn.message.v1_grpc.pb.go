@@ -0,0 +1,128 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: saturn.message.v1.proto
+
+package saturn_message_v1
+
+import (
+	context "context"
+	fatima_message_v1 "github.com/fatima-go/fatima-core/builder/fatima.message.v1"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SaturnMessageService_SendMessage_FullMethodName = "/saturn.message.v1.SaturnMessageService/SendMessage"
+)
+
+// SaturnMessageServiceClient is the client API for SaturnMessageService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// saturn only ingest rpc in addition to fatima.message.v1.FatimaMessageService
+// SendFatimaMessages and StreamFatimaMessages belong to FatimaMessageService (fatima.message.v1.proto)
+type SaturnMessageServiceClient interface {
+	SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*fatima_message_v1.SendFatimaMessageResponse, error)
+}
+
+type saturnMessageServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSaturnMessageServiceClient(cc grpc.ClientConnInterface) SaturnMessageServiceClient {
+	return &saturnMessageServiceClient{cc}
+}
+
+func (c *saturnMessageServiceClient) SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*fatima_message_v1.SendFatimaMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(fatima_message_v1.SendFatimaMessageResponse)
+	err := c.cc.Invoke(ctx, SaturnMessageService_SendMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SaturnMessageServiceServer is the server API for SaturnMessageService service.
+// All implementations must embed UnimplementedSaturnMessageServiceServer
+// for forward compatibility.
+//
+// saturn only ingest rpc in addition to fatima.message.v1.FatimaMessageService
+// SendFatimaMessages and StreamFatimaMessages belong to FatimaMessageService (fatima.message.v1.proto)
+type SaturnMessageServiceServer interface {
+	SendMessage(context.Context, *SendMessageRequest) (*fatima_message_v1.SendFatimaMessageResponse, error)
+	mustEmbedUnimplementedSaturnMessageServiceServer()
+}
+
+// UnimplementedSaturnMessageServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSaturnMessageServiceServer struct{}
+
+func (UnimplementedSaturnMessageServiceServer) SendMessage(context.Context, *SendMessageRequest) (*fatima_message_v1.SendFatimaMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendMessage not implemented")
+}
+func (UnimplementedSaturnMessageServiceServer) mustEmbedUnimplementedSaturnMessageServiceServer() {}
+func (UnimplementedSaturnMessageServiceServer) testEmbeddedByValue()                              {}
+
+// UnsafeSaturnMessageServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SaturnMessageServiceServer will
+// result in compilation errors.
+type UnsafeSaturnMessageServiceServer interface {
+	mustEmbedUnimplementedSaturnMessageServiceServer()
+}
+
+func RegisterSaturnMessageServiceServer(s grpc.ServiceRegistrar, srv SaturnMessageServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSaturnMessageServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SaturnMessageService_ServiceDesc, srv)
+}
+
+func _SaturnMessageService_SendMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaturnMessageServiceServer).SendMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SaturnMessageService_SendMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaturnMessageServiceServer).SendMessage(ctx, req.(*SendMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SaturnMessageService_ServiceDesc is the grpc.ServiceDesc for SaturnMessageService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SaturnMessageService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "saturn.message.v1.SaturnMessageService",
+	HandlerType: (*SaturnMessageServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SendMessage",
+			Handler:    _SaturnMessageService_SendMessage_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "saturn.message.v1.proto",
+}
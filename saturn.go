@@ -30,5 +30,7 @@ func main() {
 	fatimaRuntime := runtime.GetFatimaRuntime()
 	applicationExecutor := service.NewFatimaApplicationExecutor(fatimaRuntime)
 	fatimaRuntime.Register(engine.NewGrpcServer(fatimaRuntime, applicationExecutor))
+	fatimaRuntime.Register(engine.NewAdminGrpcServer(fatimaRuntime, applicationExecutor))
+	fatimaRuntime.Register(engine.NewSlackInteractionServer(fatimaRuntime, applicationExecutor))
 	fatimaRuntime.Run()
 }
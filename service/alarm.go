@@ -0,0 +1,369 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 23. 오전 11:20
+ */
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fatima-go/fatima-core"
+	"github.com/fatima-go/fatima-core/lib"
+	log "github.com/fatima-go/fatima-log"
+	"github.com/fatima-go/saturn/domain"
+)
+
+const (
+	fileStoreAlarm         = "alarm.store"
+	propAlarmStaleHours    = "alarm.stale.hours"
+	defaultAlarmStaleHours = 24
+	alarmIdLength          = 12
+	alarmRetention         = 1000 * 60 * 60 * 24 * 7 // keep closed alarm for 7 days
+	alarmCheckInterval     = time.Minute
+	alarmStartupResolver   = "startup"
+	alarmStaleResolver     = "stale"
+)
+
+// alarmTracker keeps lifecycle of alarms (firing, acknowledged, resolved, silenced) in data folder
+// store is written on state change. count of repeated alarm is written by watch
+type alarmTracker struct {
+	mutex     sync.Mutex
+	storePath string
+	alarms    []domain.Alarm
+	stale     int  // open alarm which has no occurrence for stale millis is resolved. 0 to keep
+	dirty     bool // count changed after last store
+	onChange  func(alarm domain.Alarm)
+}
+
+func newAlarmTracker(fatimaRuntime fatima.FatimaRuntime, onChange func(alarm domain.Alarm)) *alarmTracker {
+	tracker := alarmTracker{}
+	tracker.alarms = make([]domain.Alarm, 0)
+	tracker.onChange = onChange
+	tracker.stale = getConfigInt(fatimaRuntime, propAlarmStaleHours, defaultAlarmStaleHours) * 1000 * 60 * 60
+	if fatimaRuntime != nil {
+		tracker.storePath = filepath.Join(fatimaRuntime.GetEnv().GetFolderGuide().GetDataFolder(), fileStoreAlarm)
+		tracker.restore()
+		go tracker.watch()
+	}
+	return &tracker
+}
+
+// fire registers ALARM message and stamps alarm id into the message.
+// same alarm which is still open only increases count. startup message is not an alarm to track,
+// it resolves open alarms of the process instead
+func (t *alarmTracker) fire(mbus domain.MBusMessageBody, targets []string) {
+	if !mbus.IsAlarm() || mbus.Message == nil {
+		return
+	}
+
+	if mbus.IsProcessStartup() {
+		t.resolveStartup(mbus.GetSourceKey())
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := lib.CurrentTimeMillis()
+	fingerprint := mbus.GetHashsum()
+	if alarm := t.findOpen(fingerprint); alarm != nil {
+		alarm.Count++
+		alarm.LastAt = now
+		alarm.Notified = appendUnique(alarm.Notified, targets...)
+		mbus.Message[domain.MessageKeyAlarmId] = alarm.Id
+		t.dirty = true
+		return
+	}
+
+	alarm := domain.Alarm{
+		Id:          lib.RandomAlphanumeric(alarmIdLength),
+		Fingerprint: fingerprint,
+		State:       domain.AlarmStateFiring,
+		Count:       1,
+		FiredAt:     now,
+		LastAt:      now,
+		UpdatedAt:   now,
+		Notified:    append([]string{}, targets...),
+	}
+	mbus.Message[domain.MessageKeyAlarmId] = alarm.Id
+	alarm.Message = mbus.Clone()
+	t.alarms = append(t.alarms, alarm)
+	t.store()
+}
+
+// resolveStartup resolves open alarms of the process which started up.
+// notifiers are not updated because they receive the startup message itself
+func (t *alarmTracker) resolveStartup(source string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	resolved := 0
+	for i := range t.alarms {
+		if t.alarms[i].IsOpen() && t.alarms[i].Message.GetSourceKey() == source {
+			t.transit(&t.alarms[i], domain.AlarmStateResolved, alarmStartupResolver, "process started up")
+			resolved++
+		}
+	}
+	if resolved > 0 {
+		t.store()
+	}
+}
+
+// silence closes open alarm which is silenced now, or records silenced alarm once per silence
+func (t *alarmTracker) silence(mbus domain.MBusMessageBody, silence domain.Silence) {
+	if !mbus.IsAlarm() {
+		return
+	}
+
+	t.mutex.Lock()
+	now := lib.CurrentTimeMillis()
+	fingerprint := mbus.GetHashsum()
+	if alarm := t.findOpen(fingerprint); alarm != nil {
+		alarm.LastAt = now
+		changed := t.transit(alarm, domain.AlarmStateSilenced, silence.Id, silence.Comment)
+		t.store()
+		t.mutex.Unlock()
+		t.notifyChange(changed)
+		return
+	}
+
+	if alarm := t.findSilenced(fingerprint, silence.Id); alarm != nil {
+		alarm.Count++
+		alarm.LastAt = now
+		t.dirty = true
+		t.mutex.Unlock()
+		return
+	}
+
+	t.alarms = append(t.alarms, domain.Alarm{
+		Id:          lib.RandomAlphanumeric(alarmIdLength),
+		Fingerprint: fingerprint,
+		State:       domain.AlarmStateSilenced,
+		Message:     mbus.Clone(),
+		Count:       1,
+		FiredAt:     now,
+		LastAt:      now,
+		UpdatedAt:   now,
+		UpdatedBy:   silence.Id,
+		Comment:     silence.Comment,
+	})
+	t.store()
+	t.mutex.Unlock()
+}
+
+// silenceMatched closes open alarms which the new silence matches
+func (t *alarmTracker) silenceMatched(silence domain.Silence) {
+	t.mutex.Lock()
+	changed := make([]domain.Alarm, 0)
+	for i := range t.alarms {
+		if t.alarms[i].IsOpen() && silence.Matcher.Match(t.alarms[i].Message) {
+			changed = append(changed, t.transit(&t.alarms[i], domain.AlarmStateSilenced, silence.Id, silence.Comment))
+		}
+	}
+	if len(changed) > 0 {
+		t.store()
+	}
+	t.mutex.Unlock()
+
+	for _, alarm := range changed {
+		t.notifyChange(alarm)
+	}
+}
+
+func (t *alarmTracker) acknowledge(id, by, comment string) (domain.Alarm, error) {
+	return t.change(id, domain.AlarmStateAcknowledged, by, comment)
+}
+
+func (t *alarmTracker) resolve(id, by, comment string) (domain.Alarm, error) {
+	return t.change(id, domain.AlarmStateResolved, by, comment)
+}
+
+func (t *alarmTracker) change(id, state, by, comment string) (domain.Alarm, error) {
+	if len(by) == 0 {
+		return domain.Alarm{}, fmt.Errorf("%w : updatedBy is required", ErrInvalidParameter)
+	}
+
+	t.mutex.Lock()
+	alarm := t.find(id)
+	if alarm == nil {
+		t.mutex.Unlock()
+		return domain.Alarm{}, fmt.Errorf("%w : alarm %s", ErrNotFound, id)
+	}
+	if !alarm.IsOpen() {
+		t.mutex.Unlock()
+		return *alarm, fmt.Errorf("%w : alarm %s is already %s", ErrInvalidParameter, id, alarm.State)
+	}
+
+	changed := t.transit(alarm, state, by, comment)
+	t.store()
+	t.mutex.Unlock()
+
+	t.notifyChange(changed)
+	return changed, nil
+}
+
+// list returns alarms of given states (every state if empty) in fired order
+func (t *alarmTracker) list(states []string) []domain.Alarm {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	list := make([]domain.Alarm, 0, len(t.alarms))
+	for _, v := range t.alarms {
+		if len(states) > 0 && !containsString(states, v.State) {
+			continue
+		}
+		list = append(list, v)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].FiredAt < list[j].FiredAt
+	})
+	return list
+}
+
+func (t *alarmTracker) get(id string) (domain.Alarm, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	alarm := t.find(id)
+	if alarm == nil {
+		return domain.Alarm{}, fmt.Errorf("%w : alarm %s", ErrNotFound, id)
+	}
+	return *alarm, nil
+}
+
+func (t *alarmTracker) watch() {
+	ticker := time.NewTicker(alarmCheckInterval)
+	for range ticker.C {
+		t.expire(lib.CurrentTimeMillis())
+	}
+}
+
+// expire resolves stale open alarms and writes store when something changed
+func (t *alarmTracker) expire(now int) {
+	t.mutex.Lock()
+	changed := make([]domain.Alarm, 0)
+	if t.stale > 0 {
+		for i := range t.alarms {
+			if t.alarms[i].IsOpen() && now-t.alarms[i].LastAt > t.stale {
+				comment := fmt.Sprintf("no occurrence for %s", time.Duration(t.stale)*time.Millisecond)
+				changed = append(changed, t.transit(&t.alarms[i], domain.AlarmStateResolved, alarmStaleResolver, comment))
+			}
+		}
+	}
+	if len(changed) > 0 || t.dirty {
+		t.store()
+	}
+	t.mutex.Unlock()
+
+	for _, alarm := range changed {
+		t.notifyChange(alarm)
+	}
+}
+
+func (t *alarmTracker) transit(alarm *domain.Alarm, state, by, comment string) domain.Alarm {
+	log.Info("alarm %s : %s -> %s by %s", alarm.Id, alarm.State, state, by)
+	alarm.State = state
+	alarm.UpdatedAt = lib.CurrentTimeMillis()
+	alarm.UpdatedBy = by
+	alarm.Comment = comment
+	return *alarm
+}
+
+func (t *alarmTracker) notifyChange(alarm domain.Alarm) {
+	if t.onChange != nil && len(alarm.Notified) > 0 {
+		t.onChange(alarm)
+	}
+}
+
+func (t *alarmTracker) find(id string) *domain.Alarm {
+	for i := range t.alarms {
+		if t.alarms[i].Id == id {
+			return &t.alarms[i]
+		}
+	}
+	return nil
+}
+
+func (t *alarmTracker) findOpen(fingerprint string) *domain.Alarm {
+	for i := range t.alarms {
+		if t.alarms[i].Fingerprint == fingerprint && t.alarms[i].IsOpen() {
+			return &t.alarms[i]
+		}
+	}
+	return nil
+}
+
+func (t *alarmTracker) findSilenced(fingerprint, silenceId string) *domain.Alarm {
+	for i := range t.alarms {
+		if t.alarms[i].Fingerprint == fingerprint && t.alarms[i].State == domain.AlarmStateSilenced && t.alarms[i].UpdatedBy == silenceId {
+			return &t.alarms[i]
+		}
+	}
+	return nil
+}
+
+func (t *alarmTracker) restore() {
+	data, err := os.ReadFile(t.storePath)
+	if err != nil {
+		return
+	}
+
+	var alarms []domain.Alarm
+	err = json.Unmarshal(data, &alarms)
+	if err != nil {
+		log.Warn("fail to unmarshal %s : %s", fileStoreAlarm, err.Error())
+		return
+	}
+	t.alarms = alarms
+	log.Info("alarm restored : %d", len(alarms))
+}
+
+func (t *alarmTracker) store() {
+	t.dirty = false
+	if len(t.storePath) == 0 {
+		return
+	}
+
+	now := lib.CurrentTimeMillis()
+	kept := make([]domain.Alarm, 0, len(t.alarms))
+	for _, v := range t.alarms {
+		if !v.IsOpen() && now-v.UpdatedAt > alarmRetention {
+			continue
+		}
+		kept = append(kept, v)
+	}
+	t.alarms = kept
+
+	b, err := json.MarshalIndent(t.alarms, "", "  ")
+	if err != nil {
+		log.Warn("fail to build json : %s", err.Error())
+		return
+	}
+
+	err = os.WriteFile(t.storePath, b, 0644)
+	if err != nil {
+		log.Warn("fail to store %s : %s", fileStoreAlarm, err.Error())
+	}
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 23. 오전 11:20
+ */
+
+package service
+
+import (
+	"testing"
+
+	"github.com/fatima-go/saturn/domain"
+)
+
+func TestAlarmLifecycle(t *testing.T) {
+	changed := make([]domain.Alarm, 0)
+	tracker := newAlarmTracker(nil, func(alarm domain.Alarm) {
+		changed = append(changed, alarm)
+	})
+
+	m1 := buildSampleMBusBody("disk usage over 90%")
+	tracker.fire(m1, []string{"slack"})
+	m2 := buildSampleMBusBody("disk usage over 90%")
+	tracker.fire(m2, []string{"slack"})
+
+	id := m1.GetAlarmId()
+	if len(id) == 0 || id != m2.GetAlarmId() {
+		t.Fatalf("same open alarm should have same id : %s, %s", id, m2.GetAlarmId())
+	}
+
+	list := tracker.list([]string{domain.AlarmStateFiring})
+	if len(list) != 1 || list[0].Count != 2 {
+		t.Fatalf("expected 1 firing alarm with count 2 : %v", list)
+	}
+
+	if _, err := tracker.acknowledge(id, "", ""); err == nil {
+		t.Fatalf("acknowledge without name should fail")
+	}
+	if _, err := tracker.acknowledge(id, "alice", "looking"); err != nil {
+		t.Fatalf("fail to acknowledge : %s", err.Error())
+	}
+	if _, err := tracker.resolve(id, "alice", "cleaned up"); err != nil {
+		t.Fatalf("fail to resolve : %s", err.Error())
+	}
+	if _, err := tracker.resolve(id, "alice", ""); err == nil {
+		t.Fatalf("resolving closed alarm should fail")
+	}
+	if len(changed) != 2 || changed[1].State != domain.AlarmStateResolved {
+		t.Fatalf("expected 2 state changes : %v", changed)
+	}
+
+	m3 := buildSampleMBusBody("disk usage over 90%")
+	tracker.fire(m3, []string{"slack"})
+	if m3.GetAlarmId() == id {
+		t.Fatalf("alarm after resolved should have new id")
+	}
+}
+
+func TestAlarmStartupAndStale(t *testing.T) {
+	changed := make([]domain.Alarm, 0)
+	tracker := newAlarmTracker(nil, func(alarm domain.Alarm) {
+		changed = append(changed, alarm)
+	})
+
+	shutdown := buildSampleAction(domain.ActionProcessShutdown)
+	tracker.fire(shutdown, []string{"slack"})
+	if len(tracker.list([]string{domain.AlarmStateFiring})) != 1 {
+		t.Fatalf("shutdown should be firing alarm")
+	}
+
+	startup := buildSampleAction(domain.ActionProcessStartup)
+	tracker.fire(startup, []string{"slack"})
+	if len(startup.GetAlarmId()) != 0 {
+		t.Fatalf("startup should not be tracked as alarm")
+	}
+	resolved, err := tracker.get(shutdown.GetAlarmId())
+	if err != nil || resolved.State != domain.AlarmStateResolved || resolved.UpdatedBy != alarmStartupResolver {
+		t.Fatalf("startup should resolve shutdown alarm : %v", resolved)
+	}
+	if len(changed) != 0 || len(tracker.list(nil)) != 1 {
+		t.Fatalf("startup resolve should not notify state : %v", changed)
+	}
+
+	disk := buildSampleMBusBody("disk usage over 90%")
+	tracker.fire(disk, []string{"slack"})
+	alarm, _ := tracker.get(disk.GetAlarmId())
+
+	tracker.expire(alarm.LastAt + tracker.stale)
+	if alarm, _ = tracker.get(disk.GetAlarmId()); alarm.State != domain.AlarmStateFiring {
+		t.Fatalf("alarm should be open until stale timeout : %s", alarm.State)
+	}
+	tracker.expire(alarm.LastAt + tracker.stale + 1)
+	if alarm, _ = tracker.get(disk.GetAlarmId()); alarm.State != domain.AlarmStateResolved || alarm.UpdatedBy != alarmStaleResolver {
+		t.Fatalf("stale alarm should be resolved : %v", alarm)
+	}
+	if len(changed) != 1 || changed[0].Id != alarm.Id {
+		t.Fatalf("stale resolve should notify state : %v", changed)
+	}
+}
+
+func TestAlarmSilenceMatched(t *testing.T) {
+	changed := make([]domain.Alarm, 0)
+	tracker := newAlarmTracker(nil, func(alarm domain.Alarm) {
+		changed = append(changed, alarm)
+	})
+
+	matched := buildSampleMBusBody("disk usage over 90%")
+	tracker.fire(matched, []string{"slack"})
+	other := buildSampleMBusBody("memory usage over 90%")
+	other.PackageProcess = "batch"
+	tracker.fire(other, []string{"slack"})
+
+	tracker.silenceMatched(domain.Silence{Id: "s1", Matcher: domain.MessageMatcher{Process: "test"}, Comment: "maintenance"})
+	alarm, _ := tracker.get(matched.GetAlarmId())
+	if alarm.State != domain.AlarmStateSilenced || alarm.UpdatedBy != "s1" {
+		t.Fatalf("matched open alarm should be silenced : %v", alarm)
+	}
+	if alarm, _ = tracker.get(other.GetAlarmId()); alarm.State != domain.AlarmStateFiring {
+		t.Fatalf("unmatched alarm should be kept : %s", alarm.State)
+	}
+	if len(changed) != 1 || changed[0].Id != matched.GetAlarmId() {
+		t.Fatalf("silenced alarm should notify state : %v", changed)
+	}
+}
+
+func TestAlarmNotice(t *testing.T) {
+	executor := newTestExecutor([]namedNotify{{name: "slack", notify: &ackNotify{}}})
+	sample := buildSampleMBusBody("process test shutdown")
+
+	notice := sample.Clone()
+	notice.Message[domain.MessageKeyMessage] = "process test is flapping"
+	notice.Message[domain.MessageKeyFlapping] = true
+	executor.notifyAs(sample, notice)
+
+	summary := sample.Clone()
+	summary.Message[domain.MessageKeyMessage] = "message repeated 3 times"
+	summary.Message[domain.MessageKeyRepeatCount] = 3
+	executor.notifyAs(sample, summary)
+
+	alarms := executor.alarm.list(nil)
+	if len(alarms) != 1 || alarms[0].Message.GetText() != "process test is flapping" {
+		t.Fatalf("notice should fire alarm and summary should not : %v", alarms)
+	}
+	if alarms[0].Notified[0] != "slack" {
+		t.Errorf("notice alarm should keep notified notifiers : %v", alarms[0].Notified)
+	}
+}
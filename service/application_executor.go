@@ -21,47 +21,124 @@
 package service
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/fatima-go/fatima-core"
 	"github.com/fatima-go/fatima-core/builder"
+	"github.com/fatima-go/fatima-core/lib"
 	log "github.com/fatima-go/fatima-log"
 	"github.com/fatima-go/saturn/domain"
+	"github.com/fatima-go/saturn/notifier/alertmanager"
+	"github.com/fatima-go/saturn/notifier/gitissue"
+	"github.com/fatima-go/saturn/notifier/jira"
 	"github.com/fatima-go/saturn/notifier/slack"
-	"strings"
 )
 
 const (
-	categoryMonitor        = "monitor"
-	processJuno            = "juno"
 	propMessageNotifyChain = "message.notify.chain"
 )
 
-var opmProcessList = []string{"jupiter", "juno", "saturn"}
-
 type ApplicationExecutor interface {
-	Consume(m domain.MBusMessage)
+	// Consume returns ErrQueueFull (wrapped) when queue of notifier which the message is routed to is full. the message is rejected
+	// before it changes any state (dedup, alarm, page, ...) so that the sender can send it again
+	Consume(m domain.MBusMessage) error
+	ReplayOutbox()
+	SilenceExecutor
+	OncallExecutor
+	AlarmExecutor
+	DeadLetterExecutor
+	// Subscribe returns live decisions of messages which match any of matchers (everything if empty)
+	// cancel should be called when the subscriber leaves
+	Subscribe(matchers []domain.MessageMatcher) (decisions <-chan domain.Decision, cancel func(), err error)
+	// QueryAlarms returns stored decisions in newest first order and token of the next page (empty if last)
+	QueryAlarms(query HistoryQuery) (decisions []domain.Decision, nextPageToken string, err error)
+}
+
+type SilenceExecutor interface {
+	CreateSilence(silence domain.Silence) (domain.Silence, error)
+	ListSilences(includeExpired bool) []domain.Silence
+	ExpireSilence(id string) (domain.Silence, error)
+}
+
+type AlarmExecutor interface {
+	ListAlarms(states []string) []domain.Alarm
+	GetAlarm(id string) (domain.Alarm, error)
+	AcknowledgeAlarm(id, by, comment string) (domain.Alarm, error)
+	ResolveAlarm(id, by, comment string) (domain.Alarm, error)
+}
+
+type DeadLetterExecutor interface {
+	ListDeadLetters(notifier string) []domain.Delivery
+	GetDeadLetter(id string) (domain.Delivery, error)
+	RedeliverDeadLetter(id string) (domain.Delivery, error)
+	PurgeDeadLetters(ids []string, notifier string, all bool) (int, error)
+}
+
+type OncallExecutor interface {
+	ListPages(includeAcknowledged bool) []domain.Page
+	AcknowledgePage(id, by string) (domain.Page, error)
 }
 
 func NewFatimaApplicationExecutor(fatimaRuntime fatima.FatimaRuntime) ApplicationExecutor {
 	app := FatimaApplicationExecutor{}
 	app.fatimaRuntime = fatimaRuntime
 	app.notifyChain = buildMessageNotifyChain(fatimaRuntime)
+	app.deadLetter = newDeadLetterStore(fatimaRuntime)
+	app.outbox = newOutbox(fatimaRuntime, app.notifyChain, app.deadLetter.add)
+	app.router = newMessageRouter(fatimaRuntime, app.getNotifierNames(), app.notify)
+	app.filter = newMessageFilter(fatimaRuntime)
+	setupDedupRule(fatimaRuntime)
+	setRepeatHandler(app.notifyAs)
+	app.storm = newStormGuard(fatimaRuntime, app.notifyAs)
+	app.flapping = newFlappingDetector(fatimaRuntime, app.notifyAs)
+	app.restart = newRestartCorrelator(fatimaRuntime, app.notifyAs)
+	app.silence = newSilenceManager(fatimaRuntime)
+	app.feed = newDecisionFeed()
+	app.history = newMessageHistory(fatimaRuntime)
+	app.oncall = newOncallPager(fatimaRuntime, app.send)
+	app.alarm = newAlarmTracker(fatimaRuntime, app.notifyAlarmState)
 	return &app
 }
 
-func buildMessageNotifyChain(fatimaRuntime fatima.FatimaRuntime) []domain.MessageNotify {
-	chain := make([]domain.MessageNotify, 0)
+// buildMessageNotifyChain creates notifiers in chain
+// each entry is notifier type or type:key for additional instance (e.g. slack,slack:dba,jira)
+// entry itself is used as notifier name in routing rule
+func buildMessageNotifyChain(fatimaRuntime fatima.FatimaRuntime) []namedNotify {
+	chain := make([]namedNotify, 0)
 	values, ok := fatimaRuntime.GetConfig().GetValue(propMessageNotifyChain)
 	if !ok {
 		// add slack to default
-		chain = append(chain, slack.NewSlackNotification(fatimaRuntime))
+		chain = append(chain, namedNotify{name: "slack", notify: slack.NewSlackNotification(fatimaRuntime)})
 		log.Info("load notify chain : SLACK")
 		return chain
 	}
 
 	for _, v := range strings.Split(strings.TrimSpace(values), ",") {
-		if strings.ToLower(v) == "slack" {
-			chain = append(chain, slack.NewSlackNotification(fatimaRuntime))
-			log.Info("load notify chain : SLACK")
+		name := strings.ToLower(strings.TrimSpace(v))
+		kind, key, found := strings.Cut(name, ":")
+		if found && kind != "slack" {
+			log.Warn("notifier %s does not support key. ignore %s", kind, name)
+			continue
+		}
+
+		switch kind {
+		case "slack":
+			if found {
+				chain = append(chain, namedNotify{name: name, notify: slack.NewSlackNotificationWithKey(fatimaRuntime, key)})
+			} else {
+				chain = append(chain, namedNotify{name: name, notify: slack.NewSlackNotification(fatimaRuntime)})
+			}
+			log.Info("load notify chain : SLACK (%s)", name)
+		case "jira":
+			chain = append(chain, namedNotify{name: name, notify: jira.NewJiraNotification(fatimaRuntime)})
+			log.Info("load notify chain : JIRA")
+		case "gitissue":
+			chain = append(chain, namedNotify{name: name, notify: gitissue.NewGitIssueNotification(fatimaRuntime)})
+			log.Info("load notify chain : GITISSUE")
+		case "alertmanager":
+			chain = append(chain, namedNotify{name: name, notify: alertmanager.NewAlertmanagerNotification(fatimaRuntime)})
+			log.Info("load notify chain : ALERTMANAGER")
 		}
 		// TODO : more notifier will be added in future
 		// TODO : file, db, tcp, ...
@@ -69,9 +146,34 @@ func buildMessageNotifyChain(fatimaRuntime fatima.FatimaRuntime) []domain.Messag
 	return chain
 }
 
+type namedNotify struct {
+	name   string
+	notify domain.MessageNotify
+}
+
 type FatimaApplicationExecutor struct {
 	fatimaRuntime fatima.FatimaRuntime
-	notifyChain   []domain.MessageNotify
+	notifyChain   []namedNotify
+	outbox        *outbox
+	deadLetter    *deadLetterStore
+	feed          *decisionFeed
+	history       *messageHistory
+	router        *messageRouter
+	filter        *messageFilter
+	storm         *stormGuard
+	flapping      *flappingDetector
+	restart       *restartCorrelator
+	silence       *silenceManager
+	oncall        *oncallPager
+	alarm         *alarmTracker
+}
+
+func (f *FatimaApplicationExecutor) getNotifierNames() []string {
+	names := make([]string, 0, len(f.notifyChain))
+	for _, c := range f.notifyChain {
+		names = append(names, c.name)
+	}
+	return names
 }
 
 func toLogicString(logicNo int) string {
@@ -84,7 +186,7 @@ func toLogicString(logicNo int) string {
 	return "unknown logic code"
 }
 
-func (f *FatimaApplicationExecutor) Consume(m domain.MBusMessage) {
+func (f *FatimaApplicationExecutor) Consume(m domain.MBusMessage) error {
 	if log.IsDebugEnabled() {
 		log.Debug("%s :: %s:%s:%s:%s:%s",
 			toLogicString(m.Header.Logic),
@@ -97,32 +199,252 @@ func (f *FatimaApplicationExecutor) Consume(m domain.MBusMessage) {
 	}
 
 	if m.Header.Logic == builder.LogicMeasure {
-		return
+		return nil
+	}
+
+	decision, err := f.decide(m.Body)
+	decision.Id = lib.RandomAlphanumeric(historyIdLength)
+	decision.Message = m.Body.Clone()
+	f.history.record(decision)
+	f.feed.publish(decision)
+	return err
+}
+
+// decide runs the message through filter, silence, dedup and routing and returns what saturn did with it
+func (f *FatimaApplicationExecutor) decide(mbus domain.MBusMessageBody) (domain.Decision, error) {
+	decision := domain.Decision{DecidedAt: lib.CurrentTimeMillis()}
+	if f.filter.isFiltered(mbus) {
+		decision.Result = domain.DecisionFiltered
+		return decision, nil
 	}
 
-	if isOpmProcess(m.Body) {
-		return
+	// routed before any state change so that full queue of target notifier rejects the message
+	route := f.router.route(mbus)
+	if err := f.checkCapacity(route.targets); err != nil {
+		decision.Result = domain.DecisionRejected
+		return decision, err
 	}
 
-	log.Info("%v", m.Body)
-	if isRedundant(m.Body) {
-		return
+	log.Info("%v", mbus)
+	if silence, ok := f.silence.match(mbus); ok {
+		log.Info("silenced by %s (%s)", silence.Id, silence.Comment)
+		f.alarm.silence(mbus, silence)
+		decision.Result = domain.DecisionSilenced
+		decision.SilenceId = silence.Id
+		return decision, nil
 	}
 
+	if f.flapping.observe(mbus) {
+		decision.Result = domain.DecisionFlapping
+		return decision, nil
+	}
+
+	if isRedundant(mbus) {
+		decision.Result = domain.DecisionRedundant
+		return decision, nil
+	}
+
+	if !f.storm.allow(mbus) {
+		decision.Result = domain.DecisionStorm
+		return decision, nil
+	}
+
+	if f.restart.correlate(mbus) {
+		decision.Result = domain.DecisionRestart
+		return decision, nil
+	}
+
+	f.router.hold(route, mbus)
+	if route.held() {
+		decision.Result = domain.DecisionHeld
+		return decision, nil
+	}
+
+	f.notify(route.targets, mbus)
+	decision.Result = domain.DecisionNotified
+	decision.Notified = route.targets
+	return decision, nil
+}
+
+// checkCapacity returns ErrQueueFull (wrapped) when queue of any target notifier is full
+func (f *FatimaApplicationExecutor) checkCapacity(targets []string) error {
+	full := make([]string, 0)
 	for _, c := range f.notifyChain {
-		c.SendNotify(m.Body)
+		if !containsString(targets, c.name) {
+			continue
+		}
+		if q, ok := c.notify.(domain.QueueNotify); ok && q.IsQueueFull() {
+			full = append(full, c.name)
+		}
+	}
+	if len(full) > 0 {
+		return fmt.Errorf("%w : %s", ErrQueueFull, strings.Join(full, ","))
+	}
+	return nil
+}
+
+// notify fires alarm, sends message to target notifiers and pages on-call for MAJOR alarm.
+// every message saturn notifies goes through here so that notice which saturn built
+// (e.g. flapping, released shutdown) has alarm and page as well.
+// summary of other messages (repeat, storm, digest) does not fire alarm of its own
+func (f *FatimaApplicationExecutor) notify(targets []string, mbus domain.MBusMessageBody) {
+	if !mbus.IsSummary() {
+		f.alarm.fire(mbus, targets)
+	}
+	f.send(targets, mbus)
+	f.oncall.page(mbus)
+}
+
+// notifyAs sends message which saturn built (e.g. repeat summary) through the routes of original message
+func (f *FatimaApplicationExecutor) notifyAs(sample, mbus domain.MBusMessageBody) {
+	route := f.router.route(sample)
+	f.router.hold(route, mbus)
+	if !route.held() {
+		f.notify(route.targets, mbus)
 	}
 }
 
-func isOpmProcess(msg domain.MBusMessageBody) bool {
-	for _, v := range opmProcessList {
-		if msg.PackageProcess == v {
-			if msg.PackageProcess == processJuno && msg.GetCategory() == categoryMonitor {
-				return false
+// send delivers message to target notifiers. durable message goes through outbox
+// failure of other message is reported after the notifier's own retry and kept in dead letter
+// failure is not returned because the message has already changed state (dedup, alarm, page, ...)
+// and can not be sent again by the sender. capacity is checked before by checkCapacity
+func (f *FatimaApplicationExecutor) send(targets []string, mbus domain.MBusMessageBody) {
+	durable := f.outbox.isDurable(mbus)
+	for _, c := range f.notifyChain {
+		if !containsString(targets, c.name) {
+			continue
+		}
+		if durable {
+			f.outbox.send(c.name, mbus)
+			continue
+		}
+
+		reliable, ok := c.notify.(domain.ReliableNotify)
+		if !ok {
+			c.notify.SendNotify(mbus)
+			continue
+		}
+
+		name := c.name
+		reliable.SendNotifyWithAck(mbus, func(err error) {
+			if err != nil {
+				f.deadLetter.failed(name, mbus, err)
 			}
-			return true
+		})
+	}
+}
+
+// ReplayOutbox sends pending deliveries of previous run
+func (f *FatimaApplicationExecutor) ReplayOutbox() {
+	f.outbox.replay()
+}
+
+// notifyAlarmState updates notifiers which received the alarm
+func (f *FatimaApplicationExecutor) notifyAlarmState(alarm domain.Alarm) {
+	for _, c := range f.notifyChain {
+		if !containsString(alarm.Notified, c.name) {
+			continue
+		}
+		if n, ok := c.notify.(domain.AlarmStateNotify); ok {
+			n.SendAlarmState(alarm)
 		}
 	}
+}
+
+func (f *FatimaApplicationExecutor) CreateSilence(silence domain.Silence) (domain.Silence, error) {
+	created, err := f.silence.create(silence)
+	if err == nil && created.IsActive(lib.CurrentTimeMillis()) {
+		f.alarm.silenceMatched(created)
+	}
+	return created, err
+}
+
+func (f *FatimaApplicationExecutor) ListSilences(includeExpired bool) []domain.Silence {
+	return f.silence.list(includeExpired)
+}
+
+func (f *FatimaApplicationExecutor) ExpireSilence(id string) (domain.Silence, error) {
+	return f.silence.expire(id)
+}
+
+func (f *FatimaApplicationExecutor) ListPages(includeAcknowledged bool) []domain.Page {
+	return f.oncall.list(includeAcknowledged)
+}
+
+func (f *FatimaApplicationExecutor) AcknowledgePage(id, by string) (domain.Page, error) {
+	page, err := f.oncall.acknowledge(id, by)
+	if err != nil {
+		return page, err
+	}
+
+	// acknowledging page also acknowledges its alarm
+	if alarmId := page.Message.GetAlarmId(); len(alarmId) > 0 {
+		if alarm, err := f.alarm.get(alarmId); err == nil && alarm.State == domain.AlarmStateFiring {
+			f.alarm.acknowledge(alarmId, by, "page "+page.Id+" acknowledged")
+		}
+	}
+	return page, nil
+}
+
+func (f *FatimaApplicationExecutor) ListAlarms(states []string) []domain.Alarm {
+	return f.alarm.list(states)
+}
+
+func (f *FatimaApplicationExecutor) GetAlarm(id string) (domain.Alarm, error) {
+	return f.alarm.get(id)
+}
+
+func (f *FatimaApplicationExecutor) AcknowledgeAlarm(id, by, comment string) (domain.Alarm, error) {
+	alarm, err := f.alarm.acknowledge(id, by, comment)
+	if err == nil {
+		f.oncall.acknowledgeAlarm(id, by)
+	}
+	return alarm, err
+}
+
+func (f *FatimaApplicationExecutor) ResolveAlarm(id, by, comment string) (domain.Alarm, error) {
+	alarm, err := f.alarm.resolve(id, by, comment)
+	if err == nil {
+		f.oncall.acknowledgeAlarm(id, by)
+	}
+	return alarm, err
+}
+
+func (f *FatimaApplicationExecutor) ListDeadLetters(notifier string) []domain.Delivery {
+	return f.deadLetter.list(notifier)
+}
+
+func (f *FatimaApplicationExecutor) GetDeadLetter(id string) (domain.Delivery, error) {
+	return f.deadLetter.get(id)
+}
+
+// RedeliverDeadLetter takes dead letter out of the store and sends it again through outbox
+func (f *FatimaApplicationExecutor) RedeliverDeadLetter(id string) (domain.Delivery, error) {
+	letter, err := f.deadLetter.get(id)
+	if err != nil {
+		return letter, err
+	}
+	if !containsString(f.getNotifierNames(), letter.Notifier) {
+		return letter, fmt.Errorf("%w : notifier %s is not in notify chain", ErrInvalidParameter, letter.Notifier)
+	}
+
+	letter, err = f.deadLetter.remove(id)
+	if err != nil {
+		return letter, err
+	}
+	log.Info("redeliver dead letter %s to %s", letter.Id, letter.Notifier)
+	f.outbox.send(letter.Notifier, letter.Message)
+	return letter, nil
+}
+
+func (f *FatimaApplicationExecutor) PurgeDeadLetters(ids []string, notifier string, all bool) (int, error) {
+	return f.deadLetter.purge(ids, notifier, all)
+}
+
+func (f *FatimaApplicationExecutor) Subscribe(matchers []domain.MessageMatcher) (<-chan domain.Decision, func(), error) {
+	return f.feed.subscribe(matchers)
+}
 
-	return false
+func (f *FatimaApplicationExecutor) QueryAlarms(query HistoryQuery) ([]domain.Decision, string, error) {
+	return f.history.query(query)
 }
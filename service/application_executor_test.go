@@ -0,0 +1,102 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 26. 오전 10:00
+ */
+
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fatima-go/saturn/domain"
+)
+
+func newTestExecutor(chain []namedNotify) *FatimaApplicationExecutor {
+	executor := &FatimaApplicationExecutor{notifyChain: chain}
+	executor.deadLetter = newDeadLetterStore(nil)
+	executor.outbox = newOutbox(nil, chain, executor.deadLetter.add)
+	executor.router = newMessageRouter(nil, executor.getNotifierNames(), executor.notify)
+	executor.filter = newMessageFilter(nil)
+	executor.storm = newStormGuard(nil, executor.notifyAs)
+	executor.flapping = newFlappingDetector(nil, executor.notifyAs)
+	executor.restart = newRestartCorrelator(nil, executor.notifyAs)
+	executor.silence = newSilenceManager(nil)
+	executor.oncall = newOncallPager(nil, executor.send)
+	executor.alarm = newAlarmTracker(nil, executor.notifyAlarmState)
+	return executor
+}
+
+func TestDecideQueueFull(t *testing.T) {
+	full := &ackNotify{full: true}
+	working := &ackNotify{}
+	executor := newTestExecutor([]namedNotify{{name: "slack", notify: full}, {name: "jira", notify: working}})
+
+	mbus := buildSampleMBusBody("queue of notifier is full")
+	decision, err := executor.decide(mbus)
+	if !errors.Is(err, ErrQueueFull) || decision.Result != domain.DecisionRejected {
+		t.Fatalf("expected rejected decision : %s, %v", decision.Result, err)
+	}
+	if full.sent != 0 || working.sent != 0 || len(executor.alarm.list(nil)) != 0 {
+		t.Fatalf("rejected message should not change state : sent=%d,%d", full.sent, working.sent)
+	}
+
+	// sent again by sender when the queue is available
+	full.full = false
+	decision, err = executor.decide(buildSampleMBusBody("queue of notifier is full"))
+	if err != nil || decision.Result != domain.DecisionNotified {
+		t.Fatalf("retried message should be notified, not redundant : %s, %v", decision.Result, err)
+	}
+	if full.sent != 1 || working.sent != 1 {
+		t.Fatalf("expected sent to every notifier : sent=%d,%d", full.sent, working.sent)
+	}
+}
+
+func TestDecideQueueFullNotRouted(t *testing.T) {
+	full := &ackNotify{full: true}
+	working := &ackNotify{}
+	executor := newTestExecutor([]namedNotify{{name: "slack", notify: full}, {name: "jira", notify: working}})
+	executor.router.config = &RoutingConfig{Default: []string{"jira"}, Rules: []RoutingRule{}}
+
+	decision, err := executor.decide(buildSampleMBusBody("queue of other notifier is full"))
+	if err != nil || decision.Result != domain.DecisionNotified {
+		t.Fatalf("full notifier out of route should not reject : %s, %v", decision.Result, err)
+	}
+	if full.sent != 0 || working.sent != 1 {
+		t.Fatalf("expected sent to routed notifier only : sent=%d,%d", full.sent, working.sent)
+	}
+}
+
+func TestSendFailure(t *testing.T) {
+	rejecting := &ackNotify{err: &domain.AttemptError{Attempts: 5, Err: errors.New("server error : 503 Service Unavailable")}}
+	working := &ackNotify{}
+	executor := newTestExecutor([]namedNotify{{name: "slack", notify: rejecting}, {name: "jira", notify: working}})
+
+	executor.send([]string{"slack", "jira"}, buildSampleMBusBody("disk usage over 90%"))
+	if working.sent != 1 || rejecting.sent != 1 {
+		t.Fatalf("expected sent to every notifier : sent=%d,%d", rejecting.sent, working.sent)
+	}
+
+	list := executor.deadLetter.list("")
+	if len(list) != 1 || list[0].Notifier != "slack" || list[0].Attempts != 5 {
+		t.Fatalf("failed message should be kept in dead letter : %v", list)
+	}
+	if list[0].LastError != rejecting.err.Error() || list[0].Message.GetText() != "disk usage over 90%" {
+		t.Fatalf("dead letter should keep error and message : %s", list[0].LastError)
+	}
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 19. 오후 6:40
+ */
+
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fatima-go/fatima-core"
+)
+
+const (
+	dataFileCheckInterval = time.Second * 10
+)
+
+// dataFile watches a file in fatima data folder for hot reloading
+type dataFile struct {
+	path          string
+	modTime       time.Time
+	exist         bool
+	lastCheckTime time.Time
+}
+
+func newDataFile(fatimaRuntime fatima.FatimaRuntime, name string) *dataFile {
+	d := dataFile{}
+	if fatimaRuntime != nil {
+		d.path = filepath.Join(fatimaRuntime.GetEnv().GetFolderGuide().GetDataFolder(), name)
+	}
+	return &d
+}
+
+// reload returns file content when the file has been modified since last check
+// nil data with changed=true means the file has been removed
+func (d *dataFile) reload() (data []byte, changed bool) {
+	if len(d.path) == 0 {
+		return nil, false
+	}
+
+	if time.Since(d.lastCheckTime) < dataFileCheckInterval {
+		return nil, false
+	}
+	d.lastCheckTime = time.Now()
+
+	stat, err := os.Stat(d.path)
+	if err != nil {
+		if !d.exist {
+			return nil, false
+		}
+		d.exist = false
+		d.modTime = time.Time{}
+		return nil, true
+	}
+
+	if d.exist && stat.ModTime().Equal(d.modTime) {
+		return nil, false
+	}
+
+	data, err = os.ReadFile(d.path)
+	if err != nil {
+		return nil, false
+	}
+
+	d.exist = true
+	d.modTime = stat.ModTime()
+	return data, true
+}
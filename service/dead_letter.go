@@ -0,0 +1,222 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 26. 오전 10:30
+ */
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/fatima-go/fatima-core"
+	"github.com/fatima-go/fatima-core/lib"
+	log "github.com/fatima-go/fatima-log"
+	"github.com/fatima-go/saturn/domain"
+)
+
+const (
+	fileStoreDeadLetter = "dead.letter"
+	deadLetterRetention = 1000 * 60 * 60 * 24 * 7 // keep dead letter for 7 days
+)
+
+// deadLetterStore keeps deliveries which failed in data folder. outbox delivery goes here after max attempts
+// and other delivery after the notifier gave up its own retry.
+// the file is written by its own goroutine so that notifier which acknowledges delivery is not blocked
+type deadLetterStore struct {
+	mutex     sync.Mutex
+	storePath string
+	letters   []domain.Delivery
+	flush     chan struct{}
+}
+
+func newDeadLetterStore(fatimaRuntime fatima.FatimaRuntime) *deadLetterStore {
+	store := deadLetterStore{}
+	store.letters = make([]domain.Delivery, 0)
+	store.flush = make(chan struct{}, 1)
+	if fatimaRuntime != nil {
+		store.storePath = filepath.Join(fatimaRuntime.GetEnv().GetFolderGuide().GetDataFolder(), fileStoreDeadLetter)
+		store.restore()
+		go store.run()
+	}
+	return &store
+}
+
+func (s *deadLetterStore) add(delivery domain.Delivery) {
+	log.Warn("dead letter %s to %s after %d attempts : %s",
+		delivery.Id, delivery.Notifier, delivery.Attempts, delivery.LastError)
+
+	s.mutex.Lock()
+	s.letters = append(s.letters, delivery)
+	s.mutex.Unlock()
+	s.requestStore()
+}
+
+// failed keeps message out of outbox which the notifier failed to deliver
+func (s *deadLetterStore) failed(notifier string, mbus domain.MBusMessageBody, err error) {
+	now := lib.CurrentTimeMillis()
+	s.add(domain.Delivery{
+		Id:            lib.RandomAlphanumeric(outboxIdLength),
+		Notifier:      notifier,
+		Message:       mbus.Clone(),
+		CreatedAt:     now,
+		Attempts:      domain.GetAttempts(err),
+		LastError:     err.Error(),
+		LastAttemptAt: now,
+	})
+}
+
+// list returns dead letters of notifier (every notifier if empty) in created order
+func (s *deadLetterStore) list(notifier string) []domain.Delivery {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	list := make([]domain.Delivery, 0, len(s.letters))
+	for _, v := range s.letters {
+		if len(notifier) > 0 && v.Notifier != notifier {
+			continue
+		}
+		list = append(list, v)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt < list[j].CreatedAt
+	})
+	return list
+}
+
+func (s *deadLetterStore) get(id string) (domain.Delivery, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, v := range s.letters {
+		if v.Id == id {
+			return v, nil
+		}
+	}
+	return domain.Delivery{}, fmt.Errorf("%w : dead letter %s", ErrNotFound, id)
+}
+
+// remove takes dead letter out of the store (e.g. for redelivery)
+func (s *deadLetterStore) remove(id string) (domain.Delivery, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i, v := range s.letters {
+		if v.Id == id {
+			s.letters = append(s.letters[:i], s.letters[i+1:]...)
+			s.requestStore()
+			return v, nil
+		}
+	}
+	return domain.Delivery{}, fmt.Errorf("%w : dead letter %s", ErrNotFound, id)
+}
+
+// purge removes dead letters of given ids and notifier. every dead letter is removed only when all is set
+// returns the number of removed dead letters
+func (s *deadLetterStore) purge(ids []string, notifier string, all bool) (int, error) {
+	if len(ids) == 0 && len(notifier) == 0 && !all {
+		return 0, fmt.Errorf("%w : ids or notifier is required to purge (all to purge every dead letter)", ErrInvalidParameter)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	kept := make([]domain.Delivery, 0, len(s.letters))
+	for _, v := range s.letters {
+		if len(ids) > 0 && !containsString(ids, v.Id) {
+			kept = append(kept, v)
+			continue
+		}
+		if len(notifier) > 0 && v.Notifier != notifier {
+			kept = append(kept, v)
+			continue
+		}
+	}
+
+	purged := len(s.letters) - len(kept)
+	if purged > 0 {
+		s.letters = kept
+		s.requestStore()
+		log.Info("%d dead letters purged", purged)
+	}
+	return purged, nil
+}
+
+func (s *deadLetterStore) restore() {
+	data, err := os.ReadFile(s.storePath)
+	if err != nil {
+		return
+	}
+
+	var letters []domain.Delivery
+	err = json.Unmarshal(data, &letters)
+	if err != nil {
+		log.Warn("fail to unmarshal %s : %s", fileStoreDeadLetter, err.Error())
+		return
+	}
+	s.letters = letters
+	log.Info("dead letter restored : %d", len(letters))
+}
+
+// requestStore wakes up writer. requests while writing are merged into one
+func (s *deadLetterStore) requestStore() {
+	select {
+	case s.flush <- struct{}{}:
+	default:
+	}
+}
+
+func (s *deadLetterStore) run() {
+	for range s.flush {
+		s.store()
+	}
+}
+
+func (s *deadLetterStore) store() {
+	if len(s.storePath) == 0 {
+		return
+	}
+
+	now := lib.CurrentTimeMillis()
+	s.mutex.Lock()
+	kept := make([]domain.Delivery, 0, len(s.letters))
+	for _, v := range s.letters {
+		if now-v.LastAttemptAt > deadLetterRetention {
+			continue
+		}
+		kept = append(kept, v)
+	}
+	s.letters = kept
+	letters := append([]domain.Delivery{}, kept...)
+	s.mutex.Unlock()
+
+	b, err := json.Marshal(letters)
+	if err != nil {
+		log.Warn("fail to build json : %s", err.Error())
+		return
+	}
+
+	err = os.WriteFile(s.storePath, b, 0644)
+	if err != nil {
+		log.Warn("fail to store %s : %s", fileStoreDeadLetter, err.Error())
+	}
+}
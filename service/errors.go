@@ -0,0 +1,35 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 29. 오후 3:00
+ */
+
+package service
+
+import (
+	"errors"
+
+	"github.com/fatima-go/saturn/domain"
+)
+
+// errors of service. they are wrapped with detail and mapped to grpc response by engine
+var (
+	ErrInvalidParameter = errors.New("invalid parameter")
+	ErrNotFound         = errors.New("not found")
+	ErrNotAcceptable    = errors.New("not acceptable")
+	ErrQueueFull        = domain.ErrQueueFull
+)
@@ -0,0 +1,128 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 20. 오전 10:10
+ */
+
+package service
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/fatima-go/fatima-core"
+	log "github.com/fatima-go/fatima-log"
+	"github.com/fatima-go/saturn/domain"
+)
+
+const (
+	fileRuleFilter  = "rule.filter"
+	categoryMonitor = "monitor"
+	processJuno     = "juno"
+)
+
+// FilterConfig is the content of rule.filter file in data folder
+// message which matches any exclude matcher is dropped unless it also matches an include matcher
+type FilterConfig struct {
+	Include []domain.MessageMatcher `json:"include,omitempty"`
+	Exclude []domain.MessageMatcher `json:"exclude,omitempty"`
+}
+
+// defaultFilterConfig mutes opm processes(jupiter, juno, saturn) except juno monitor message
+func defaultFilterConfig() FilterConfig {
+	return FilterConfig{
+		Include: []domain.MessageMatcher{
+			{Process: processJuno, Category: categoryMonitor},
+		},
+		Exclude: []domain.MessageMatcher{
+			{Process: "jupiter"},
+			{Process: processJuno},
+			{Process: "saturn"},
+		},
+	}
+}
+
+type messageFilter struct {
+	mutex  sync.Mutex
+	file   *dataFile
+	config FilterConfig
+}
+
+func newMessageFilter(fatimaRuntime fatima.FatimaRuntime) *messageFilter {
+	filter := messageFilter{}
+	filter.file = newDataFile(fatimaRuntime, fileRuleFilter)
+	filter.config = defaultFilterConfig()
+	return &filter
+}
+
+// isFiltered returns true when the message should not be notified
+func (f *messageFilter) isFiltered(mbus domain.MBusMessageBody) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.loading()
+	if !matchAny(f.config.Exclude, mbus) {
+		return false
+	}
+	return !matchAny(f.config.Include, mbus)
+}
+
+func (f *messageFilter) loading() {
+	data, changed := f.file.reload()
+	if !changed {
+		return
+	}
+
+	if data == nil {
+		log.Info("%s removed. use default filter", fileRuleFilter)
+		f.config = defaultFilterConfig()
+		return
+	}
+
+	var config FilterConfig
+	err := json.Unmarshal(data, &config)
+	if err != nil {
+		log.Warn("fail to unmarshal %s : %s", fileRuleFilter, err.Error())
+		return
+	}
+
+	config.Include = compileMatchers(config.Include)
+	config.Exclude = compileMatchers(config.Exclude)
+	f.config = config
+	log.Info("filter rule loaded : include[%d], exclude[%d]", len(config.Include), len(config.Exclude))
+}
+
+func compileMatchers(list []domain.MessageMatcher) []domain.MessageMatcher {
+	compiled := make([]domain.MessageMatcher, 0, len(list))
+	for _, m := range list {
+		if err := m.Compile(); err != nil {
+			log.Warn("skip matcher : %s", err.Error())
+			continue
+		}
+		compiled = append(compiled, m)
+	}
+	return compiled
+}
+
+func matchAny(list []domain.MessageMatcher, mbus domain.MBusMessageBody) bool {
+	for i := range list {
+		if list[i].Match(mbus) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 29. 오전 10:00
+ */
+
+package service
+
+import (
+	"testing"
+
+	"github.com/fatima-go/saturn/domain"
+)
+
+func TestDefaultFilter(t *testing.T) {
+	filter := newMessageFilter(nil)
+
+	cases := []struct {
+		process  string
+		category string
+		filtered bool
+	}{
+		{"jupiter", "", true},
+		{"saturn", "", true},
+		{"juno", "", true},
+		{"juno", categoryMonitor, false}, // juno monitor message is notified
+		{"batch", "", false},
+		{"batch", categoryMonitor, false},
+	}
+
+	for _, c := range cases {
+		m := buildSampleMBusBody("process shutdowned")
+		m.PackageProcess = c.process
+		if len(c.category) > 0 {
+			m.Message[domain.MessageKeyCategory] = c.category
+		}
+		if filter.isFiltered(m) != c.filtered {
+			t.Fatalf("%s(%s) : expected filtered=%v", c.process, c.category, c.filtered)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 20. 오후 5:10
+ */
+
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fatima-go/fatima-core"
+	log "github.com/fatima-go/fatima-log"
+	"github.com/fatima-go/saturn/domain"
+)
+
+const (
+	propFlappingThreshold = "flapping.threshold"
+	propFlappingWindow    = "flapping.window"
+
+	defaultFlappingThreshold = 6  // state changes
+	defaultFlappingWindow    = 10 // minutes
+)
+
+type processState struct {
+	transitions []time.Time
+	flapping    bool
+	restarts    int // startup count while flapping
+	escalation  int // restarts count of next escalating alarm
+	last        domain.MBusMessageBody
+}
+
+// flappingDetector marks process as flapping when it changes state more than threshold within window
+// while flapping, start/stop messages are replaced with single escalating flapping alarm
+type flappingDetector struct {
+	mutex     sync.Mutex
+	threshold int
+	window    time.Duration
+	processes map[string]*processState
+	notify    func(sample, notice domain.MBusMessageBody)
+}
+
+func newFlappingDetector(fatimaRuntime fatima.FatimaRuntime, notify func(sample, notice domain.MBusMessageBody)) *flappingDetector {
+	detector := flappingDetector{}
+	detector.threshold = getConfigInt(fatimaRuntime, propFlappingThreshold, defaultFlappingThreshold)
+	detector.window = time.Minute * time.Duration(getConfigInt(fatimaRuntime, propFlappingWindow, defaultFlappingWindow))
+	detector.processes = make(map[string]*processState)
+	detector.notify = notify
+
+	log.Info("flapping detector : threshold=%d, window=%s", detector.threshold, detector.window)
+	if detector.threshold > 0 {
+		go detector.watch()
+	}
+	return &detector
+}
+
+// observe returns true when the message is absorbed by flapping alarm
+func (d *flappingDetector) observe(mbus domain.MBusMessageBody) bool {
+	return d.observeAt(mbus, time.Now())
+}
+
+func (d *flappingDetector) observeAt(mbus domain.MBusMessageBody, now time.Time) bool {
+	if d.threshold <= 0 || !mbus.IsAlarm() || !mbus.IsProcessStartupOrShutdown() {
+		return false
+	}
+
+	d.mutex.Lock()
+	state, ok := d.processes[mbus.GetSourceKey()]
+	if !ok {
+		state = &processState{}
+		d.processes[mbus.GetSourceKey()] = state
+	}
+
+	state.last = mbus
+	state.transitions = append(trimTransitions(state.transitions, now.Add(-d.window)), now)
+
+	if !state.flapping {
+		if len(state.transitions) <= d.threshold {
+			d.mutex.Unlock()
+			return false
+		}
+
+		state.flapping = true
+		state.restarts = countRestarts(len(state.transitions))
+		state.escalation = state.restarts * 2
+		restarts := state.restarts
+		d.mutex.Unlock()
+
+		log.Warn("%s is flapping (%d restarts)", mbus.GetSourceKey(), restarts)
+		d.sendFlapping(mbus, fmt.Sprintf("process %s is flapping (%d restarts in %s)", mbus.PackageProcess, restarts, d.window))
+		return true
+	}
+
+	if mbus.IsProcessStartup() {
+		state.restarts++
+	}
+
+	escalate := state.restarts >= state.escalation
+	restarts := state.restarts
+	if escalate {
+		state.escalation = state.restarts * 2
+	}
+	d.mutex.Unlock()
+
+	if escalate {
+		d.sendFlapping(mbus, fmt.Sprintf("process %s is still flapping (%d restarts)", mbus.PackageProcess, restarts))
+	}
+	return true
+}
+
+// watch sends stabilized message when flapping process has no state change during window
+func (d *flappingDetector) watch() {
+	ticker := time.NewTicker(time.Second * 10)
+	for range ticker.C {
+		d.stabilize(time.Now())
+	}
+}
+
+func (d *flappingDetector) stabilize(now time.Time) {
+	stabilized := make([]processState, 0)
+
+	d.mutex.Lock()
+	for k, state := range d.processes {
+		state.transitions = trimTransitions(state.transitions, now.Add(-d.window))
+		if len(state.transitions) > 0 {
+			continue
+		}
+		if state.flapping {
+			stabilized = append(stabilized, *state)
+		}
+		delete(d.processes, k)
+	}
+	d.mutex.Unlock()
+
+	for _, state := range stabilized {
+		d.sendStabilized(state)
+	}
+}
+
+func (d *flappingDetector) sendFlapping(sample domain.MBusMessageBody, text string) {
+	notice := sample.Clone()
+	notice.EventTime = int(time.Now().UnixMilli())
+	delete(notice.Message, domain.MessageKeyDeployment)
+	delete(notice.Message, domain.MessageKeyAction)
+	notice.Message[domain.MessageKeyMessage] = text
+	notice.Message[domain.MessageKeyAlarmLevel] = domain.AlarmLevelMajor
+	notice.Message[domain.MessageKeyFlapping] = true
+	d.notify(sample, notice)
+}
+
+// sendStabilized reports final state with last start/stop message so that notifiers can resolve or keep alarm
+func (d *flappingDetector) sendStabilized(state processState) {
+	current := "stopped"
+	if state.last.IsProcessStartup() {
+		current = "running"
+	}
+
+	text := fmt.Sprintf("process %s stabilized after %d restarts (%s)", state.last.PackageProcess, state.restarts, current)
+	log.Info("%s : %s", state.last.GetSourceKey(), text)
+
+	notice := state.last.Clone()
+	notice.Message[domain.MessageKeyMessage] = text
+	notice.Message[domain.MessageKeyFlapping] = false
+	d.notify(state.last, notice)
+}
+
+func trimTransitions(transitions []time.Time, deadline time.Time) []time.Time {
+	for i, t := range transitions {
+		if t.After(deadline) {
+			return transitions[i:]
+		}
+	}
+	return transitions[:0]
+}
+
+// countRestarts : shutdown and startup makes one restart
+func countRestarts(transitions int) int {
+	return (transitions + 1) / 2
+}
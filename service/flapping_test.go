@@ -0,0 +1,113 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 29. 오전 11:00
+ */
+
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatima-go/saturn/domain"
+)
+
+func buildSampleAction(action string) domain.MBusMessageBody {
+	mbus := buildSampleMBusBody("process " + action)
+	mbus.Message[domain.MessageKeyAction] = action
+	return mbus
+}
+
+func newTestFlappingDetector(notices *[]domain.MBusMessageBody) *flappingDetector {
+	detector := newFlappingDetector(nil, func(sample, notice domain.MBusMessageBody) {
+		*notices = append(*notices, notice)
+	})
+	detector.threshold = 2
+	detector.window = time.Minute
+	return detector
+}
+
+func TestFlappingThreshold(t *testing.T) {
+	notices := make([]domain.MBusMessageBody, 0)
+	detector := newTestFlappingDetector(&notices)
+
+	now := time.Now()
+	if detector.observeAt(buildSampleMBusBody("disk usage over 90%"), now) {
+		t.Fatalf("alarm other than start/stop should not be absorbed")
+	}
+	if detector.observeAt(buildSampleAction(domain.ActionProcessShutdown), now) ||
+		detector.observeAt(buildSampleAction(domain.ActionProcessStartup), now.Add(time.Second)) {
+		t.Fatalf("state changes within threshold should pass")
+	}
+	if !detector.observeAt(buildSampleAction(domain.ActionProcessShutdown), now.Add(time.Second*2)) {
+		t.Fatalf("state change over threshold should be absorbed")
+	}
+	if len(notices) != 1 || !strings.Contains(notices[0].GetText(), "is flapping (2 restarts") {
+		t.Fatalf("expected flapping notice : %v", notices)
+	}
+	if notices[0].Message[domain.MessageKeyFlapping] != true || !notices[0].IsMajorAlarm() {
+		t.Fatalf("flapping notice should be major alarm : %v", notices[0].Message)
+	}
+
+	// absorbed until restarts reach escalation (twice of restarts at detection)
+	detector.observeAt(buildSampleAction(domain.ActionProcessStartup), now.Add(time.Second*3))
+	detector.observeAt(buildSampleAction(domain.ActionProcessShutdown), now.Add(time.Second*4))
+	if len(notices) != 1 {
+		t.Fatalf("unexpected escalation : %v", notices)
+	}
+	detector.observeAt(buildSampleAction(domain.ActionProcessStartup), now.Add(time.Second*5))
+	if len(notices) != 2 || !strings.Contains(notices[1].GetText(), "still flapping (4 restarts)") {
+		t.Fatalf("expected escalating notice : %v", notices)
+	}
+
+	// stabilized when no state change during window
+	detector.stabilize(now.Add(time.Second * 30))
+	if len(notices) != 2 {
+		t.Fatalf("should not be stabilized within window : %v", notices)
+	}
+	detector.stabilize(now.Add(time.Minute + time.Second*6))
+	if len(notices) != 3 || !strings.Contains(notices[2].GetText(), "stabilized after 4 restarts (running)") {
+		t.Fatalf("expected stabilized notice : %v", notices)
+	}
+	if notices[2].Message[domain.MessageKeyFlapping] != false || len(detector.processes) != 0 {
+		t.Fatalf("process should be cleared after stabilized")
+	}
+}
+
+func TestFlappingWindow(t *testing.T) {
+	notices := make([]domain.MBusMessageBody, 0)
+	detector := newTestFlappingDetector(&notices)
+
+	now := time.Now()
+	actions := []string{domain.ActionProcessShutdown, domain.ActionProcessStartup}
+	for i := 0; i < 6; i++ {
+		// state changes are spread wider than window
+		if detector.observeAt(buildSampleAction(actions[i%2]), now.Add(time.Second*time.Duration(i*40))) {
+			t.Fatalf("state changes out of window should not be flapping : %d", i)
+		}
+	}
+	if len(notices) != 0 {
+		t.Fatalf("unexpected notices : %v", notices)
+	}
+
+	detector.stabilize(now.Add(time.Hour))
+	if len(notices) != 0 || len(detector.processes) != 0 {
+		t.Fatalf("process not flapping should be cleared silently : %v", notices)
+	}
+}
@@ -0,0 +1,277 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 28. 오전 11:00
+ */
+
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatima-go/fatima-core"
+	"github.com/fatima-go/fatima-core/lib"
+	log "github.com/fatima-go/fatima-log"
+	"github.com/fatima-go/saturn/domain"
+)
+
+const (
+	folderHistory           = "history"
+	historyFileSuffix       = ".history"
+	historyDayLayout        = "20060102"
+	propHistoryRetention    = "history.retention.days"
+	defaultHistoryRetention = 7
+	historyIdLength         = 8
+	defaultHistoryPageSize  = 100
+	maxHistoryPageSize      = 1000
+)
+
+// HistoryQuery is condition of message history. time values are unix millis
+type HistoryQuery struct {
+	From      int                     // retention days ago if 0
+	To        int                     // now if 0
+	Matchers  []domain.MessageMatcher // any of matchers. every message if empty
+	Text      string                  // case insensitive text in message, host or process
+	Results   []string                // decision results. every result if empty
+	PageSize  int
+	PageToken string // next page token of previous query
+}
+
+// messageHistory keeps decisions of accepted messages in daily files (data/history/yyyyMMdd.history)
+type messageHistory struct {
+	mutex     sync.Mutex
+	folder    string
+	retention int // days. disabled if 0
+	day       string
+	file      *os.File
+}
+
+func newMessageHistory(fatimaRuntime fatima.FatimaRuntime) *messageHistory {
+	history := messageHistory{}
+	history.retention = getConfigInt(fatimaRuntime, propHistoryRetention, defaultHistoryRetention)
+	if fatimaRuntime == nil || history.retention <= 0 {
+		return &history
+	}
+
+	history.folder = filepath.Join(fatimaRuntime.GetEnv().GetFolderGuide().GetDataFolder(), folderHistory)
+	if err := os.MkdirAll(history.folder, 0755); err != nil {
+		log.Warn("fail to create %s : %s", history.folder, err.Error())
+		history.folder = ""
+	}
+	return &history
+}
+
+func (h *messageHistory) isEnabled() bool {
+	return len(h.folder) > 0 && h.retention > 0
+}
+
+// record appends decision to the file of the day
+func (h *messageHistory) record(decision domain.Decision) {
+	if !h.isEnabled() {
+		return
+	}
+
+	b, err := json.Marshal(decision)
+	if err != nil {
+		log.Warn("fail to build json : %s", err.Error())
+		return
+	}
+	b = append(b, '\n')
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	day := time.UnixMilli(int64(decision.DecidedAt)).Format(historyDayLayout)
+	if day != h.day || h.file == nil {
+		h.rotate(day)
+	}
+	if h.file == nil {
+		return
+	}
+	if _, err = h.file.Write(b); err != nil {
+		log.Warn("fail to write history : %s", err.Error())
+	}
+}
+
+// rotate opens file of the day and removes files out of retention. should be called with lock
+func (h *messageHistory) rotate(day string) {
+	if h.file != nil {
+		h.file.Close()
+		h.file = nil
+	}
+
+	var err error
+	h.day = day
+	h.file, err = os.OpenFile(h.path(day), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		log.Warn("fail to open history : %s", err.Error())
+		h.file = nil
+	}
+
+	oldest := time.Now().AddDate(0, 0, -h.retention).Format(historyDayLayout)
+	entries, _ := os.ReadDir(h.folder)
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), historyFileSuffix)
+		if ok && name < oldest {
+			log.Info("remove history %s", e.Name())
+			os.Remove(filepath.Join(h.folder, e.Name()))
+		}
+	}
+}
+
+func (h *messageHistory) path(day string) string {
+	return filepath.Join(h.folder, day+historyFileSuffix)
+}
+
+// query returns decisions which match the query in newest first order and token of the next page
+func (h *messageHistory) query(q HistoryQuery) ([]domain.Decision, string, error) {
+	if !h.isEnabled() {
+		return nil, "", fmt.Errorf("%w : history is disabled", ErrInvalidParameter)
+	}
+
+	for i := range q.Matchers {
+		if err := q.Matchers[i].Compile(); err != nil {
+			return nil, "", fmt.Errorf("%w : %s", ErrInvalidParameter, err.Error())
+		}
+	}
+
+	cursor, err := parseHistoryToken(q.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := lib.CurrentTimeMillis()
+	if q.To == 0 || q.To > now {
+		q.To = now
+	}
+	if q.From == 0 {
+		q.From = int(time.UnixMilli(int64(q.To)).AddDate(0, 0, -h.retention).UnixMilli())
+	}
+	if q.From > q.To {
+		return nil, "", fmt.Errorf("%w : from is after to", ErrInvalidParameter)
+	}
+	if q.PageSize <= 0 {
+		q.PageSize = defaultHistoryPageSize
+	}
+	q.PageSize = min(q.PageSize, maxHistoryPageSize)
+	q.Text = strings.ToLower(q.Text)
+
+	list := make([]domain.Decision, 0)
+	first := time.UnixMilli(int64(q.From)).Format(historyDayLayout)
+	for day := time.UnixMilli(int64(q.To)); day.Format(historyDayLayout) >= first; day = day.AddDate(0, 0, -1) {
+		list = append(list, h.scan(day.Format(historyDayLayout), q, cursor)...)
+		if len(list) > q.PageSize {
+			break
+		}
+	}
+
+	if len(list) <= q.PageSize {
+		return list, "", nil
+	}
+	list = list[:q.PageSize]
+	last := list[len(list)-1]
+	return list, fmt.Sprintf("%d.%s", last.DecidedAt, last.Id), nil
+}
+
+// scan returns matched decisions of the day in newest first order
+func (h *messageHistory) scan(day string, q HistoryQuery, cursor historyCursor) []domain.Decision {
+	file, err := os.Open(h.path(day))
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	list := make([]domain.Decision, 0)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var decision domain.Decision
+		if err := json.Unmarshal(scanner.Bytes(), &decision); err != nil {
+			continue // partially written line
+		}
+		if decision.DecidedAt < q.From || decision.DecidedAt > q.To || !cursor.isAfter(decision) {
+			continue
+		}
+		if len(q.Matchers) > 0 && !matchAny(q.Matchers, decision.Message) {
+			continue
+		}
+		if len(q.Results) > 0 && !containsString(q.Results, decision.Result) {
+			continue
+		}
+		if len(q.Text) > 0 && !containsText(decision.Message, q.Text) {
+			continue
+		}
+		list = append(list, decision)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return newerThan(list[i], list[j])
+	})
+	return list
+}
+
+func containsText(mbus domain.MBusMessageBody, text string) bool {
+	for _, v := range []string{mbus.GetText(), mbus.PackageHost, mbus.PackageProcess} {
+		if strings.Contains(strings.ToLower(v), text) {
+			return true
+		}
+	}
+	return false
+}
+
+func newerThan(a, b domain.Decision) bool {
+	if a.DecidedAt != b.DecidedAt {
+		return a.DecidedAt > b.DecidedAt
+	}
+	return a.Id > b.Id
+}
+
+// historyCursor is the last decision of previous page
+type historyCursor struct {
+	decidedAt int
+	id        string
+}
+
+func parseHistoryToken(token string) (historyCursor, error) {
+	if len(token) == 0 {
+		return historyCursor{}, nil
+	}
+
+	at, id, found := strings.Cut(token, ".")
+	decidedAt, err := strconv.Atoi(at)
+	if !found || err != nil {
+		return historyCursor{}, fmt.Errorf("%w : invalid page token %s", ErrInvalidParameter, token)
+	}
+	return historyCursor{decidedAt: decidedAt, id: id}, nil
+}
+
+// isAfter returns true when the decision comes after the cursor in newest first order
+func (c historyCursor) isAfter(decision domain.Decision) bool {
+	if c.decidedAt == 0 {
+		return true
+	}
+	return newerThan(domain.Decision{DecidedAt: c.decidedAt, Id: c.id}, decision)
+}
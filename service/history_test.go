@@ -0,0 +1,87 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 28. 오전 11:00
+ */
+
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fatima-go/fatima-core/lib"
+	"github.com/fatima-go/saturn/domain"
+)
+
+func TestMessageHistory(t *testing.T) {
+	history := newMessageHistory(nil)
+	history.folder = t.TempDir()
+
+	now := lib.CurrentTimeMillis()
+	for i := 0; i < 5; i++ {
+		m := buildSampleMBusBody(fmt.Sprintf("disk usage over 9%d%%", i))
+		m.PackageHost = fmt.Sprintf("host%d", i%2)
+		result := domain.DecisionNotified
+		if i%2 == 1 {
+			result = domain.DecisionRedundant
+		}
+		history.record(domain.Decision{Id: fmt.Sprintf("id%d", i), Message: m, Result: result, DecidedAt: now - i*1000})
+	}
+	old := buildSampleMBusBody("process shutdowned")
+	twoDaysAgo := int(time.Now().AddDate(0, 0, -2).UnixMilli())
+	history.record(domain.Decision{Id: "old", Message: old, Result: domain.DecisionSilenced, DecidedAt: twoDaysAgo})
+
+	// pagination in newest first order
+	ids := make([]string, 0)
+	token := ""
+	for pages := 0; pages < 10; pages++ {
+		list, next, err := history.query(HistoryQuery{PageSize: 2, PageToken: token})
+		if err != nil {
+			t.Fatalf("fail to query : %s", err.Error())
+		}
+		for _, v := range list {
+			ids = append(ids, v.Id)
+		}
+		if len(next) == 0 {
+			break
+		}
+		token = next
+	}
+	if fmt.Sprint(ids) != "[id0 id1 id2 id3 id4 old]" {
+		t.Fatalf("unexpected pages : %v", ids)
+	}
+
+	list, _, _ := history.query(HistoryQuery{Results: []string{domain.DecisionRedundant}})
+	if len(list) != 2 {
+		t.Fatalf("expected 2 redundant : %v", list)
+	}
+	list, _, _ = history.query(HistoryQuery{Matchers: []domain.MessageMatcher{{Host: "host0"}}, Text: "OVER 94"})
+	if len(list) != 1 || list[0].Id != "id4" {
+		t.Fatalf("expected id4 : %v", list)
+	}
+	list, _, _ = history.query(HistoryQuery{From: now - 1500})
+	if len(list) != 2 {
+		t.Fatalf("expected 2 in time range : %v", list)
+	}
+
+	if _, _, err := history.query(HistoryQuery{PageToken: "broken"}); !errors.Is(err, ErrInvalidParameter) {
+		t.Fatalf("invalid page token should be rejected : %v", err)
+	}
+}
@@ -0,0 +1,457 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 22. 오후 4:10
+ */
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatima-go/fatima-core"
+	"github.com/fatima-go/fatima-core/lib"
+	log "github.com/fatima-go/fatima-log"
+	"github.com/fatima-go/saturn/domain"
+)
+
+const (
+	fileOncallSchedule = "oncall.schedule"
+	fileStorePage      = "oncall.page"
+	pageIdLength       = 12
+	pageRetention      = 1000 * 60 * 60 * 24 * 7 // keep acknowledged page for 7 days
+	defaultPageTimeout = time.Minute * 15
+)
+
+// OncallConfig is the content of oncall.schedule file in data folder
+type OncallConfig struct {
+	Members   map[string]OncallMember   `json:"members"`
+	Schedules map[string]OncallSchedule `json:"schedules"`
+	Policies  []EscalationPolicy        `json:"policies"`
+}
+
+// OncallMember is a person who can be paged through notifiers
+type OncallMember struct {
+	Notify  []string `json:"notify"`
+	Mention string   `json:"mention,omitempty"` // prepended to page text (e.g. <@U012AB3CD> for slack)
+}
+
+// OncallSchedule rotates members every rotation period from start
+type OncallSchedule struct {
+	Members   []string         `json:"members"`
+	Start     string           `json:"start"`    // RFC3339 (e.g. 2026-10-05T09:00:00+09:00)
+	Rotation  string           `json:"rotation"` // e.g. 168h
+	Overrides []OncallOverride `json:"overrides,omitempty"`
+
+	start    time.Time
+	rotation time.Duration
+}
+
+// OncallOverride replaces on-call member between from and to (RFC3339)
+type OncallOverride struct {
+	Member string `json:"member"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+// EscalationPolicy pages levels in order. when page is not acknowledged within timeout of level,
+// next level is paged
+type EscalationPolicy struct {
+	Name   string                `json:"name"`
+	Match  domain.MessageMatcher `json:"match"`
+	Levels []EscalationLevel     `json:"levels"`
+}
+
+// EscalationLevel pages current member of schedule, or delivers to notifiers directly
+type EscalationLevel struct {
+	Schedule string   `json:"schedule,omitempty"`
+	Notify   []string `json:"notify,omitempty"`
+	Timeout  string   `json:"timeout,omitempty"` // e.g. 10m. default 15m
+
+	timeout time.Duration
+}
+
+func (s *OncallSchedule) compile() error {
+	if len(s.Members) == 0 {
+		return fmt.Errorf("no member")
+	}
+
+	var err error
+	s.start, err = time.Parse(time.RFC3339, s.Start)
+	if err != nil {
+		return fmt.Errorf("invalid start %s", s.Start)
+	}
+	s.rotation, err = time.ParseDuration(s.Rotation)
+	if err != nil || s.rotation <= 0 {
+		return fmt.Errorf("invalid rotation %s", s.Rotation)
+	}
+	return nil
+}
+
+// current returns member on duty at the time
+func (s *OncallSchedule) current(now time.Time) string {
+	for _, o := range s.Overrides {
+		from, err1 := time.Parse(time.RFC3339, o.From)
+		to, err2 := time.Parse(time.RFC3339, o.To)
+		if err1 == nil && err2 == nil && !now.Before(from) && now.Before(to) {
+			return o.Member
+		}
+	}
+
+	elapsed := now.Sub(s.start)
+	if elapsed < 0 {
+		return s.Members[0]
+	}
+	return s.Members[int(elapsed/s.rotation)%len(s.Members)]
+}
+
+// oncallPager creates page for MAJOR alarm and escalates it until acknowledged
+type oncallPager struct {
+	mutex     sync.Mutex
+	file      *dataFile
+	config    *OncallConfig // nil when schedule file does not exist
+	storePath string
+	pages     []domain.Page
+	deliver   func(targets []string, mbus domain.MBusMessageBody)
+}
+
+func newOncallPager(fatimaRuntime fatima.FatimaRuntime, deliver func(targets []string, mbus domain.MBusMessageBody)) *oncallPager {
+	pager := oncallPager{}
+	pager.file = newDataFile(fatimaRuntime, fileOncallSchedule)
+	pager.deliver = deliver
+	pager.pages = make([]domain.Page, 0)
+	if fatimaRuntime != nil {
+		pager.storePath = filepath.Join(fatimaRuntime.GetEnv().GetFolderGuide().GetDataFolder(), fileStorePage)
+		pager.restore()
+	}
+	go pager.watch()
+	return &pager
+}
+
+// page starts escalation when MAJOR alarm matches a policy
+func (o *oncallPager) page(mbus domain.MBusMessageBody) {
+	if !mbus.IsMajorAlarm() {
+		return
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.loading()
+	if o.config == nil {
+		return
+	}
+
+	for _, policy := range o.config.Policies {
+		if len(policy.Levels) == 0 || !policy.Match.Match(mbus) {
+			continue
+		}
+
+		if o.hasOpenPage(policy.Name, mbus.GetSourceKey()) {
+			log.Info("page of policy [%s] for %s is already open", policy.Name, mbus.GetSourceKey())
+			return
+		}
+
+		now := lib.CurrentTimeMillis()
+		p := domain.Page{
+			Id:          lib.RandomAlphanumeric(pageIdLength),
+			Policy:      policy.Name,
+			Message:     mbus.Clone(),
+			CreatedAt:   now,
+			EscalatedAt: now,
+		}
+		o.notifyLevel(&p, policy)
+		o.pages = append(o.pages, p)
+		o.store()
+		return
+	}
+}
+
+func (o *oncallPager) acknowledge(id, by string) (domain.Page, error) {
+	if len(by) == 0 {
+		return domain.Page{}, fmt.Errorf("%w : acknowledgedBy is required", ErrInvalidParameter)
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	for i := range o.pages {
+		if o.pages[i].Id != id {
+			continue
+		}
+		if !o.pages[i].IsAcknowledged() {
+			o.pages[i].AcknowledgedBy = by
+			o.pages[i].AcknowledgedAt = lib.CurrentTimeMillis()
+			o.store()
+			log.Info("page %s acknowledged by %s", id, by)
+		}
+		return o.pages[i], nil
+	}
+	return domain.Page{}, fmt.Errorf("%w : page %s", ErrNotFound, id)
+}
+
+// acknowledgeAlarm stops escalation of pages for the alarm
+func (o *oncallPager) acknowledgeAlarm(alarmId, by string) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	changed := false
+	for i := range o.pages {
+		p := &o.pages[i]
+		if p.IsAcknowledged() || p.Message.GetAlarmId() != alarmId {
+			continue
+		}
+		p.AcknowledgedBy = by
+		p.AcknowledgedAt = lib.CurrentTimeMillis()
+		log.Info("page %s acknowledged with alarm %s by %s", p.Id, alarmId, by)
+		changed = true
+	}
+
+	if changed {
+		o.store()
+	}
+}
+
+func (o *oncallPager) list(includeAcknowledged bool) []domain.Page {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	list := make([]domain.Page, 0, len(o.pages))
+	for _, p := range o.pages {
+		if !includeAcknowledged && p.IsAcknowledged() {
+			continue
+		}
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt < list[j].CreatedAt
+	})
+	return list
+}
+
+func (o *oncallPager) hasOpenPage(policy, source string) bool {
+	for _, p := range o.pages {
+		if p.Policy == policy && !p.IsAcknowledged() && p.Message.GetSourceKey() == source {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *oncallPager) watch() {
+	ticker := time.NewTicker(time.Second * 10)
+	for range ticker.C {
+		o.escalate()
+	}
+}
+
+// escalate pages next level when current level is not acknowledged within its timeout
+func (o *oncallPager) escalate() {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.loading()
+	if o.config == nil {
+		return
+	}
+
+	now := lib.CurrentTimeMillis()
+	changed := false
+	for i := range o.pages {
+		p := &o.pages[i]
+		if p.IsAcknowledged() {
+			continue
+		}
+
+		policy, ok := o.findPolicy(p.Policy)
+		if !ok || p.Level+1 >= len(policy.Levels) {
+			continue // nothing to escalate
+		}
+
+		timeout := policy.Levels[p.Level].timeout
+		if now-p.EscalatedAt < int(timeout.Milliseconds()) {
+			continue
+		}
+
+		p.Level++
+		p.EscalatedAt = now
+		log.Warn("page %s is not acknowledged in %s. escalate to level %d", p.Id, timeout, p.Level)
+		o.notifyLevel(p, policy)
+		changed = true
+	}
+
+	if changed {
+		o.store()
+	}
+}
+
+func (o *oncallPager) findPolicy(name string) (EscalationPolicy, bool) {
+	for _, policy := range o.config.Policies {
+		if policy.Name == name {
+			return policy, true
+		}
+	}
+	return EscalationPolicy{}, false
+}
+
+func (o *oncallPager) notifyLevel(p *domain.Page, policy EscalationPolicy) {
+	level := policy.Levels[p.Level]
+	targets := level.Notify
+	mention := ""
+	p.Oncall = ""
+
+	if len(level.Schedule) > 0 {
+		schedule, ok := o.config.Schedules[level.Schedule]
+		if !ok {
+			log.Warn("policy [%s] : unknown schedule %s", policy.Name, level.Schedule)
+		} else {
+			p.Oncall = schedule.current(time.Now())
+			member := o.config.Members[p.Oncall]
+			targets = appendUnique(append([]string{}, targets...), member.Notify...)
+			mention = member.Mention
+		}
+	}
+
+	if len(targets) == 0 {
+		log.Warn("page %s : no notifier at level %d of policy [%s]", p.Id, p.Level, policy.Name)
+		return
+	}
+
+	msg := p.Message.Clone()
+	var buff strings.Builder
+	if len(mention) > 0 {
+		buff.WriteString(mention)
+		buff.WriteString(" ")
+	}
+	buff.WriteString(fmt.Sprintf("page %s (level %d)", p.Id, p.Level+1))
+	if len(p.Oncall) > 0 {
+		buff.WriteString(fmt.Sprintf(" to %s", p.Oncall))
+	}
+	buff.WriteString(". acknowledge with AcknowledgePage\n")
+	buff.WriteString(p.Message.GetText())
+	msg.Message[domain.MessageKeyMessage] = buff.String()
+	msg.Message[domain.MessageKeyPage] = p.Id
+
+	log.Info("page %s : level %d, oncall[%s], notify%v", p.Id, p.Level, p.Oncall, targets)
+	if o.deliver != nil {
+		o.deliver(targets, msg)
+	}
+}
+
+func (o *oncallPager) loading() {
+	data, changed := o.file.reload()
+	if !changed {
+		return
+	}
+
+	if data == nil {
+		log.Info("%s removed. on-call paging disabled", fileOncallSchedule)
+		o.config = nil
+		return
+	}
+
+	var config OncallConfig
+	err := json.Unmarshal(data, &config)
+	if err != nil {
+		log.Warn("fail to unmarshal %s : %s", fileOncallSchedule, err.Error())
+		return
+	}
+
+	for name, schedule := range config.Schedules {
+		if err = schedule.compile(); err != nil {
+			log.Warn("skip schedule [%s] : %s", name, err.Error())
+			delete(config.Schedules, name)
+			continue
+		}
+		config.Schedules[name] = schedule
+	}
+
+	policies := make([]EscalationPolicy, 0, len(config.Policies))
+	for _, policy := range config.Policies {
+		if err = policy.Match.Compile(); err != nil {
+			log.Warn("skip escalation policy [%s] : %s", policy.Name, err.Error())
+			continue
+		}
+		for i := range policy.Levels {
+			policy.Levels[i].timeout = defaultPageTimeout
+			if len(policy.Levels[i].Timeout) == 0 {
+				continue
+			}
+			d, err := time.ParseDuration(policy.Levels[i].Timeout)
+			if err != nil {
+				log.Warn("escalation policy [%s] : invalid timeout %s", policy.Name, policy.Levels[i].Timeout)
+				continue
+			}
+			policy.Levels[i].timeout = d
+		}
+		policies = append(policies, policy)
+	}
+	config.Policies = policies
+
+	o.config = &config
+	log.Info("oncall loaded : members[%d], schedules[%d], policies[%d]", len(config.Members), len(config.Schedules), len(config.Policies))
+}
+
+func (o *oncallPager) restore() {
+	data, err := os.ReadFile(o.storePath)
+	if err != nil {
+		return
+	}
+
+	var pages []domain.Page
+	err = json.Unmarshal(data, &pages)
+	if err != nil {
+		log.Warn("fail to unmarshal %s : %s", fileStorePage, err.Error())
+		return
+	}
+	o.pages = pages
+	log.Info("page restored : %d", len(pages))
+}
+
+func (o *oncallPager) store() {
+	if len(o.storePath) == 0 {
+		return
+	}
+
+	now := lib.CurrentTimeMillis()
+	kept := make([]domain.Page, 0, len(o.pages))
+	for _, p := range o.pages {
+		if p.IsAcknowledged() && now-p.AcknowledgedAt > pageRetention {
+			continue
+		}
+		kept = append(kept, p)
+	}
+	o.pages = kept
+
+	b, err := json.MarshalIndent(o.pages, "", "  ")
+	if err != nil {
+		log.Warn("fail to build json : %s", err.Error())
+		return
+	}
+
+	err = os.WriteFile(o.storePath, b, 0644)
+	if err != nil {
+		log.Warn("fail to store %s : %s", fileStorePage, err.Error())
+	}
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 22. 오후 4:10
+ */
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fatima-go/saturn/domain"
+)
+
+func TestOncallSchedule(t *testing.T) {
+	schedule := OncallSchedule{
+		Members:  []string{"alice", "bob", "carol"},
+		Start:    "2026-10-05T09:00:00+09:00",
+		Rotation: "168h",
+		Overrides: []OncallOverride{
+			{Member: "dave", From: "2026-10-20T00:00:00+09:00", To: "2026-10-21T00:00:00+09:00"},
+		},
+	}
+	if err := schedule.compile(); err != nil {
+		t.Fatalf("compile : %s", err.Error())
+	}
+
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	cases := map[time.Time]string{
+		time.Date(2026, 10, 5, 9, 0, 0, 0, seoul):   "alice",
+		time.Date(2026, 10, 12, 8, 59, 0, 0, seoul): "alice",
+		time.Date(2026, 10, 12, 9, 0, 0, 0, seoul):  "bob",
+		time.Date(2026, 10, 20, 12, 0, 0, 0, seoul): "dave",
+		time.Date(2026, 10, 26, 9, 0, 0, 0, seoul):  "alice",
+	}
+	for at, expected := range cases {
+		if oncall := schedule.current(at); oncall != expected {
+			t.Errorf("%v : expected %s but %s", at, expected, oncall)
+		}
+	}
+
+	invalid := OncallSchedule{Members: []string{"alice"}, Start: "2026-10-05", Rotation: "168h"}
+	if invalid.compile() == nil {
+		t.Errorf("invalid start should fail")
+	}
+}
+
+func TestOncallPageNotice(t *testing.T) {
+	notify := &ackNotify{}
+	executor := newTestExecutor([]namedNotify{{name: "slack", notify: notify}, {name: "slack:oncall", notify: &ackNotify{}}})
+	executor.oncall.config = &OncallConfig{
+		Members:   map[string]OncallMember{"alice": {Notify: []string{"slack:oncall"}}},
+		Schedules: map[string]OncallSchedule{},
+		Policies: []EscalationPolicy{
+			{Name: "ops", Match: domain.MessageMatcher{AlarmLevel: domain.AlarmLevelMajor}, Levels: []EscalationLevel{{Notify: []string{"slack:oncall"}}}},
+		},
+	}
+
+	sample := buildSampleAction(domain.ActionProcessStartup)
+	notice := sample.Clone()
+	delete(notice.Message, domain.MessageKeyAction)
+	notice.Message[domain.MessageKeyMessage] = "process test is flapping"
+	notice.Message[domain.MessageKeyAlarmLevel] = domain.AlarmLevelMajor
+	notice.Message[domain.MessageKeyFlapping] = true
+	executor.notifyAs(sample, notice)
+
+	pages := executor.oncall.list(true)
+	if len(pages) != 1 || pages[0].Message.GetText() != "process test is flapping" {
+		t.Fatalf("MAJOR notice should be paged : %v", pages)
+	}
+	if len(pages[0].Message.GetAlarmId()) == 0 {
+		t.Errorf("page should have alarm id of the notice")
+	}
+}
@@ -0,0 +1,374 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 25. 오후 3:10
+ */
+
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fatima-go/fatima-core"
+	"github.com/fatima-go/fatima-core/lib"
+	log "github.com/fatima-go/fatima-log"
+	"github.com/fatima-go/saturn/domain"
+)
+
+const (
+	fileOutboxJournal      = "outbox.journal"
+	propOutboxAll          = "outbox.all"
+	propOutboxMaxAttempts  = "outbox.max.attempts"
+	defaultMaxAttempts     = 10
+	outboxIdLength         = 16
+	outboxCheckInterval    = time.Second * 10
+	outboxMinBackoff       = 1000 * 30      // 30 seconds
+	outboxMaxBackoff       = 1000 * 60 * 10 // 10 minutes
+	outboxInflightTimeout  = 1000 * 60 * 5  // unacknowledged delivery is retried after 5 minutes
+	outboxCompactThreshold = 1000
+
+	outboxOpAdd  = "add"
+	outboxOpDone = "done"
+	outboxOpFail = "fail"
+	outboxOpDead = "dead"
+)
+
+// outboxRecord is a line of outbox journal
+type outboxRecord struct {
+	Op       string           `json:"op"`
+	Id       string           `json:"id"`
+	Delivery *domain.Delivery `json:"delivery,omitempty"`
+	Error    string           `json:"error,omitempty"`
+	At       int              `json:"at"`
+}
+
+type outboxEntry struct {
+	delivery    domain.Delivery
+	inflight    bool
+	nextAttempt int
+}
+
+// outbox is write-ahead journal of deliveries. delivery is appended before it is sent and marked done
+// when the notifier acknowledges it. pending deliveries are replayed at startup and retried with backoff
+// delivery which fails maxAttempts times goes to dead letter
+type outbox struct {
+	mutex       sync.Mutex
+	path        string
+	journal     *os.File
+	records     int // records in journal since last compaction
+	entries     map[string]*outboxEntry
+	notifiers   map[string]domain.MessageNotify
+	all         bool // keep every message. MAJOR alarm only if false
+	maxAttempts int
+	onDead      func(delivery domain.Delivery)
+	replayOnce  sync.Once
+}
+
+func newOutbox(fatimaRuntime fatima.FatimaRuntime, chain []namedNotify, onDead func(delivery domain.Delivery)) *outbox {
+	o := outbox{}
+	o.maxAttempts = defaultMaxAttempts
+	o.onDead = onDead
+	o.entries = make(map[string]*outboxEntry)
+	o.notifiers = make(map[string]domain.MessageNotify)
+	for _, c := range chain {
+		o.notifiers[c.name] = c.notify
+	}
+
+	if fatimaRuntime == nil {
+		return &o
+	}
+
+	if all, err := fatimaRuntime.GetConfig().GetString(propOutboxAll); err == nil && all == "true" {
+		o.all = true
+	}
+	if value, err := fatimaRuntime.GetConfig().GetInt(propOutboxMaxAttempts); err == nil && value > 0 {
+		o.maxAttempts = value
+	}
+
+	o.path = filepath.Join(fatimaRuntime.GetEnv().GetFolderGuide().GetDataFolder(), fileOutboxJournal)
+	o.restore()
+	o.compact()
+	return &o
+}
+
+// isDurable returns true when the message should be delivered at least once
+func (o *outbox) isDurable(mbus domain.MBusMessageBody) bool {
+	return o.all || mbus.IsMajorAlarm()
+}
+
+// send appends delivery to journal and sends it
+func (o *outbox) send(name string, mbus domain.MBusMessageBody) {
+	now := lib.CurrentTimeMillis()
+	entry := &outboxEntry{delivery: domain.Delivery{
+		Id:        lib.RandomAlphanumeric(outboxIdLength),
+		Notifier:  name,
+		Message:   mbus.Clone(),
+		CreatedAt: now,
+	}}
+
+	o.mutex.Lock()
+	o.append(outboxRecord{Op: outboxOpAdd, Id: entry.delivery.Id, Delivery: &entry.delivery, At: now})
+	o.entries[entry.delivery.Id] = entry
+	o.begin(entry)
+	o.mutex.Unlock()
+
+	o.dispatch(entry.delivery)
+}
+
+// replay sends pending deliveries of previous run and starts retry loop
+func (o *outbox) replay() {
+	o.replayOnce.Do(func() {
+		o.mutex.Lock()
+		pending := make([]domain.Delivery, 0, len(o.entries))
+		for _, entry := range o.entries {
+			if entry.inflight {
+				continue
+			}
+			o.begin(entry)
+			pending = append(pending, entry.delivery)
+		}
+		o.mutex.Unlock()
+
+		if len(pending) > 0 {
+			log.Info("replay %d pending deliveries in outbox", len(pending))
+		}
+		for _, d := range pending {
+			o.dispatch(d)
+		}
+		go o.watch()
+	})
+}
+
+// begin marks entry as inflight. should be called with lock
+func (o *outbox) begin(entry *outboxEntry) {
+	entry.inflight = true
+	entry.delivery.Attempts++
+	entry.delivery.LastAttemptAt = lib.CurrentTimeMillis()
+}
+
+// dispatch sends delivery to its notifier. should be called without lock
+// because notifier may acknowledge synchronously
+func (o *outbox) dispatch(d domain.Delivery) {
+	notify, ok := o.notifiers[d.Notifier]
+	if !ok {
+		log.Warn("outbox : unknown notifier %s. drop delivery %s", d.Notifier, d.Id)
+		o.ack(d.Id, nil)
+		return
+	}
+
+	if reliable, ok := notify.(domain.ReliableNotify); ok {
+		reliable.SendNotifyWithAck(d.Message, func(err error) {
+			o.ack(d.Id, err)
+		})
+		return
+	}
+
+	// notifier which does not report result is regarded as delivered
+	notify.SendNotify(d.Message)
+	o.ack(d.Id, nil)
+}
+
+// ack marks delivery done, or schedules retry when failed. delivery which failed maxAttempts times is dead
+func (o *outbox) ack(id string, err error) {
+	o.mutex.Lock()
+	entry, ok := o.entries[id]
+	if !ok {
+		o.mutex.Unlock()
+		return
+	}
+
+	now := lib.CurrentTimeMillis()
+	if err == nil {
+		o.finish(outboxRecord{Op: outboxOpDone, Id: id, At: now})
+		o.mutex.Unlock()
+		return
+	}
+
+	entry.inflight = false
+	entry.delivery.LastError = err.Error()
+	log.Warn("outbox : delivery %s to %s failed (attempt %d) : %s", id, entry.delivery.Notifier, entry.delivery.Attempts, err.Error())
+	if entry.delivery.Attempts < o.maxAttempts {
+		entry.nextAttempt = now + backoffMillis(entry.delivery.Attempts)
+		o.append(outboxRecord{Op: outboxOpFail, Id: id, Error: err.Error(), At: now})
+		o.mutex.Unlock()
+		return
+	}
+
+	o.finish(outboxRecord{Op: outboxOpDead, Id: id, Error: err.Error(), At: now})
+	o.mutex.Unlock()
+	if o.onDead != nil {
+		o.onDead(entry.delivery)
+	}
+}
+
+// finish removes entry with done or dead record. should be called with lock
+func (o *outbox) finish(record outboxRecord) {
+	o.append(record)
+	delete(o.entries, record.Id)
+	if o.records > outboxCompactThreshold {
+		o.compactLocked()
+	}
+}
+
+func (o *outbox) watch() {
+	ticker := time.NewTicker(outboxCheckInterval)
+	for range ticker.C {
+		o.retry()
+	}
+}
+
+func (o *outbox) retry() {
+	now := lib.CurrentTimeMillis()
+	due := make([]domain.Delivery, 0)
+
+	o.mutex.Lock()
+	for _, entry := range o.entries {
+		if entry.inflight && now-entry.delivery.LastAttemptAt < outboxInflightTimeout {
+			continue
+		}
+		if !entry.inflight && entry.nextAttempt > now {
+			continue
+		}
+		o.begin(entry)
+		due = append(due, entry.delivery)
+	}
+	o.mutex.Unlock()
+
+	for _, d := range due {
+		log.Info("outbox : retry delivery %s to %s (attempt %d)", d.Id, d.Notifier, d.Attempts)
+		o.dispatch(d)
+	}
+}
+
+func backoffMillis(attempts int) int {
+	backoff := outboxMinBackoff
+	for i := 1; i < attempts && backoff < outboxMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, outboxMaxBackoff)
+}
+
+// append writes record to journal. should be called with lock
+func (o *outbox) append(record outboxRecord) {
+	if o.journal == nil {
+		return
+	}
+
+	b, err := json.Marshal(record)
+	if err != nil {
+		log.Warn("fail to build json : %s", err.Error())
+		return
+	}
+
+	b = append(b, '\n')
+	if _, err = o.journal.Write(b); err != nil {
+		log.Warn("fail to write %s : %s", fileOutboxJournal, err.Error())
+		return
+	}
+	if record.Op == outboxOpAdd {
+		o.journal.Sync()
+	}
+	o.records++
+}
+
+// restore reads journal and rebuilds pending deliveries
+func (o *outbox) restore() {
+	file, err := os.Open(o.path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var record outboxRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			log.Warn("skip broken outbox record : %s", err.Error())
+			continue
+		}
+
+		switch record.Op {
+		case outboxOpAdd:
+			if record.Delivery != nil {
+				o.entries[record.Id] = &outboxEntry{delivery: *record.Delivery}
+			}
+		case outboxOpDone, outboxOpDead:
+			delete(o.entries, record.Id)
+		case outboxOpFail:
+			if entry, ok := o.entries[record.Id]; ok {
+				entry.delivery.Attempts++
+				entry.delivery.LastError = record.Error
+				entry.delivery.LastAttemptAt = record.At
+			}
+		}
+	}
+
+	if len(o.entries) > 0 {
+		log.Info("outbox restored : %d pending deliveries", len(o.entries))
+	}
+}
+
+func (o *outbox) compact() {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.compactLocked()
+}
+
+// compactLocked rewrites journal with pending deliveries only. should be called with lock
+func (o *outbox) compactLocked() {
+	if len(o.path) == 0 {
+		return
+	}
+
+	if o.journal != nil {
+		o.journal.Close()
+		o.journal = nil
+	}
+
+	temp := o.path + ".tmp"
+	file, err := os.OpenFile(temp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		log.Warn("fail to create %s : %s", temp, err.Error())
+		return
+	}
+
+	o.journal = file
+	o.records = 0
+	for id, entry := range o.entries {
+		delivery := entry.delivery
+		o.append(outboxRecord{Op: outboxOpAdd, Id: id, Delivery: &delivery, At: delivery.CreatedAt})
+	}
+	file.Sync()
+	file.Close()
+	o.journal = nil
+
+	if err = os.Rename(temp, o.path); err != nil {
+		log.Warn("fail to replace %s : %s", fileOutboxJournal, err.Error())
+	}
+
+	o.journal, err = os.OpenFile(o.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		log.Warn("fail to open %s : %s", fileOutboxJournal, err.Error())
+		o.journal = nil
+	}
+}
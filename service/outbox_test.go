@@ -0,0 +1,142 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 25. 오후 3:10
+ */
+
+package service
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/fatima-go/saturn/domain"
+)
+
+type ackNotify struct {
+	err  error
+	sent int
+	full bool
+}
+
+func (a *ackNotify) IsQueueFull() bool {
+	return a.full
+}
+
+func (a *ackNotify) SendNotify(mbus domain.MBusMessageBody) {
+	a.SendNotifyWithAck(mbus, nil)
+}
+
+func (a *ackNotify) SendNotifyWithAck(mbus domain.MBusMessageBody, done func(err error)) {
+	a.sent++
+	if done != nil {
+		done(a.err)
+	}
+}
+
+func newTestOutbox(path string, notify domain.MessageNotify) *outbox {
+	o := newOutbox(nil, []namedNotify{{name: "slack", notify: notify}}, nil)
+	o.path = path
+	o.restore()
+	o.compact()
+	return o
+}
+
+func TestOutboxReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), fileOutboxJournal)
+
+	failing := &ackNotify{err: errors.New("connection refused")}
+	o := newTestOutbox(path, failing)
+	o.send("slack", buildSampleMBusBody("disk usage over 90%"))
+	o.send("slack", buildSampleMBusBody("process shutdowned"))
+	if failing.sent != 2 || len(o.entries) != 2 {
+		t.Fatalf("expected 2 pending deliveries : sent=%d, pending=%d", failing.sent, len(o.entries))
+	}
+	for _, entry := range o.entries {
+		if entry.inflight || entry.delivery.LastError != "connection refused" {
+			t.Fatalf("failed delivery should wait retry : %v", entry)
+		}
+	}
+
+	// restart : pending deliveries are restored and replayed
+	working := &ackNotify{}
+	restored := newTestOutbox(path, working)
+	if len(restored.entries) != 2 {
+		t.Fatalf("expected 2 restored deliveries : %d", len(restored.entries))
+	}
+	for _, entry := range restored.entries {
+		if entry.delivery.Attempts != 1 || entry.delivery.Notifier != "slack" {
+			t.Fatalf("unexpected restored delivery : %v", entry.delivery)
+		}
+	}
+
+	restored.replayOnce.Do(func() {}) // do not start retry loop
+	restored.retry()
+	if working.sent != 2 || len(restored.entries) != 0 {
+		t.Fatalf("expected 2 delivered : sent=%d, pending=%d", working.sent, len(restored.entries))
+	}
+
+	if again := newTestOutbox(path, working); len(again.entries) != 0 {
+		t.Fatalf("delivered entries should not be restored : %d", len(again.entries))
+	}
+}
+
+func TestBackoffMillis(t *testing.T) {
+	if backoffMillis(1) != outboxMinBackoff || backoffMillis(2) != outboxMinBackoff*2 {
+		t.Fatalf("unexpected backoff : %d, %d", backoffMillis(1), backoffMillis(2))
+	}
+	if backoffMillis(100) != outboxMaxBackoff {
+		t.Fatalf("backoff should be capped : %d", backoffMillis(100))
+	}
+}
+
+func TestOutboxDeadLetter(t *testing.T) {
+	letters := newDeadLetterStore(nil)
+	failing := &ackNotify{err: errors.New("channel_not_found")}
+	o := newOutbox(nil, []namedNotify{{name: "slack", notify: failing}}, letters.add)
+	o.maxAttempts = 2
+	o.replayOnce.Do(func() {}) // do not start retry loop
+
+	o.send("slack", buildSampleMBusBody("disk usage over 90%"))
+	for _, entry := range o.entries {
+		entry.nextAttempt = 0
+	}
+	o.retry()
+	if len(o.entries) != 0 {
+		t.Fatalf("delivery failed max attempts should leave outbox : %d", len(o.entries))
+	}
+
+	list := letters.list("slack")
+	if len(list) != 1 || list[0].Attempts != 2 || list[0].LastError != "channel_not_found" {
+		t.Fatalf("unexpected dead letters : %v", list)
+	}
+	if _, err := letters.get(list[0].Id); err != nil {
+		t.Fatalf("fail to get dead letter : %s", err.Error())
+	}
+
+	letters.add(domain.Delivery{Id: "other", Notifier: "jira"})
+	if _, err := letters.purge(nil, "", false); !errors.Is(err, ErrInvalidParameter) {
+		t.Fatalf("purge without criteria should be rejected : %v", err)
+	}
+	if purged, err := letters.purge(nil, "jira", false); err != nil || purged != 1 {
+		t.Fatalf("expected 1 purged : %d, %v", purged, err)
+	}
+	if _, err := letters.remove(list[0].Id); err != nil || len(letters.list("")) != 0 {
+		t.Fatalf("dead letter should be removed : %v", err)
+	}
+}
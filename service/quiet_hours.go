@@ -0,0 +1,392 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 22. 오전 10:30
+ */
+
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatima-go/fatima-core"
+	log "github.com/fatima-go/fatima-log"
+	"github.com/fatima-go/saturn/domain"
+)
+
+const (
+	fileHolidayCalendar      = "holiday.calendar"
+	fileStoreDigest          = "digest.store"
+	propDigestMaxMessages    = "digest.max.messages"
+	defaultDigestMaxMessages = 500
+	holidayExclude           = "exclude"
+	holidayOnly              = "only"
+	maxDigestLines           = 50
+	digestCheckInterval      = time.Second * 30
+)
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// TimeCondition restricts routing rule to time window
+// e.g. {"days":"weekday","hours":"09:00-18:00","timezone":"Asia/Seoul","holidays":"exclude","outside":true}
+// matches out of business hours (night, weekend and holidays)
+type TimeCondition struct {
+	Days     string `json:"days,omitempty"`     // weekday, weekend or list (e.g. mon,wed,fri). every day if empty
+	Hours    string `json:"hours,omitempty"`    // e.g. 09:00-18:00, 22:00-07:00. all day if empty
+	Timezone string `json:"timezone,omitempty"` // e.g. Asia/Seoul. local if empty
+	Holidays string `json:"holidays,omitempty"` // exclude : holiday is out of window, only : only holiday is in window
+	Outside  bool   `json:"outside,omitempty"`  // match when the time is out of window
+
+	location *time.Location
+	days     [7]bool
+	fromMin  int
+	toMin    int
+	allDay   bool
+}
+
+func (c *TimeCondition) compile() error {
+	c.location = time.Local
+	if len(c.Timezone) > 0 {
+		loc, err := time.LoadLocation(c.Timezone)
+		if err != nil {
+			return fmt.Errorf("invalid timezone %s : %s", c.Timezone, err.Error())
+		}
+		c.location = loc
+	}
+
+	switch strings.ToLower(strings.TrimSpace(c.Days)) {
+	case "", "everyday":
+		c.days = [7]bool{true, true, true, true, true, true, true}
+	case "weekday":
+		c.days = [7]bool{false, true, true, true, true, true, false}
+	case "weekend":
+		c.days = [7]bool{true, false, false, false, false, false, true}
+	default:
+		c.days = [7]bool{}
+		for _, d := range strings.Split(c.Days, ",") {
+			weekday, ok := weekdayNames[strings.ToLower(strings.TrimSpace(d))]
+			if !ok {
+				return fmt.Errorf("invalid day %s", d)
+			}
+			c.days[weekday] = true
+		}
+	}
+
+	c.allDay = len(c.Hours) == 0
+	if !c.allDay {
+		from, to, found := strings.Cut(c.Hours, "-")
+		if !found {
+			return fmt.Errorf("invalid hours %s", c.Hours)
+		}
+		var err error
+		if c.fromMin, err = parseClock(from); err != nil {
+			return err
+		}
+		if c.toMin, err = parseClock(to); err != nil {
+			return err
+		}
+	}
+
+	switch c.Holidays {
+	case "", holidayExclude, holidayOnly:
+	default:
+		return fmt.Errorf("invalid holidays %s", c.Holidays)
+	}
+	return nil
+}
+
+func (c *TimeCondition) match(now time.Time, calendar *holidayCalendar) bool {
+	t := now.In(c.location)
+	in := c.days[t.Weekday()] && c.inHours(t)
+
+	if in && len(c.Holidays) > 0 {
+		holiday := calendar.isHoliday(t)
+		if c.Holidays == holidayExclude && holiday {
+			in = false
+		} else if c.Holidays == holidayOnly && !holiday {
+			in = false
+		}
+	}
+
+	if c.Outside {
+		return !in
+	}
+	return in
+}
+
+func (c *TimeCondition) inHours(t time.Time) bool {
+	if c.allDay {
+		return true
+	}
+
+	minute := t.Hour()*60 + t.Minute()
+	if c.fromMin < c.toMin {
+		return c.fromMin <= minute && minute < c.toMin
+	}
+	// wraps midnight (e.g. 22:00-07:00)
+	return minute >= c.fromMin || minute < c.toMin
+}
+
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid clock %s", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// holidayCalendar is list of date (e.g. ["2026-01-01","2026-02-17"]) in holiday.calendar file of data folder
+type holidayCalendar struct {
+	mutex sync.Mutex
+	file  *dataFile
+	days  map[string]bool
+}
+
+func newHolidayCalendar(fatimaRuntime fatima.FatimaRuntime) *holidayCalendar {
+	calendar := holidayCalendar{}
+	calendar.file = newDataFile(fatimaRuntime, fileHolidayCalendar)
+	calendar.days = make(map[string]bool)
+	return &calendar
+}
+
+func (h *holidayCalendar) isHoliday(t time.Time) bool {
+	if h == nil {
+		return false
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.loading()
+	return h.days[t.Format("2006-01-02")]
+}
+
+func (h *holidayCalendar) loading() {
+	data, changed := h.file.reload()
+	if !changed {
+		return
+	}
+
+	days := make(map[string]bool)
+	if data != nil {
+		var list []string
+		err := json.Unmarshal(data, &list)
+		if err != nil {
+			log.Warn("fail to unmarshal %s : %s", fileHolidayCalendar, err.Error())
+			return
+		}
+		for _, d := range list {
+			days[strings.TrimSpace(d)] = true
+		}
+	}
+	h.days = days
+	log.Info("holiday calendar loaded : %d", len(days))
+}
+
+type heldDigest struct {
+	Rule     string                   `json:"rule"`
+	Targets  []string                 `json:"targets"`
+	Messages []domain.MBusMessageBody `json:"messages"`
+	Dropped  int                      `json:"dropped,omitempty"` // messages over max which are counted only
+}
+
+// count returns number of held messages including dropped ones
+func (h *heldDigest) count() int {
+	return len(h.Messages) + h.Dropped
+}
+
+// digestHolder keeps messages of digest rule during its time window and delivers them at once
+// when the window ends (e.g. morning digest of night WARN/MINOR alarms).
+// held messages are kept up to max per rule and written to data folder by watch
+type digestHolder struct {
+	mutex     sync.Mutex
+	storePath string
+	max       int
+	held      map[string]*heldDigest
+	dirty     bool // held after last store
+	holding   func(rule string, now time.Time) bool
+	deliver   func(targets []string, mbus domain.MBusMessageBody)
+}
+
+func newDigestHolder(fatimaRuntime fatima.FatimaRuntime, holding func(rule string, now time.Time) bool, deliver func(targets []string, mbus domain.MBusMessageBody)) *digestHolder {
+	holder := digestHolder{}
+	holder.held = make(map[string]*heldDigest)
+	holder.max = getConfigInt(fatimaRuntime, propDigestMaxMessages, defaultDigestMaxMessages)
+	holder.holding = holding
+	holder.deliver = deliver
+	if fatimaRuntime != nil {
+		holder.storePath = filepath.Join(fatimaRuntime.GetEnv().GetFolderGuide().GetDataFolder(), fileStoreDigest)
+		holder.restore()
+	}
+	go holder.watch()
+	return &holder
+}
+
+func (d *digestHolder) hold(rule string, targets []string, mbus domain.MBusMessageBody) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	key := rule + "|" + strings.Join(targets, ",")
+	h, ok := d.held[key]
+	if !ok {
+		h = &heldDigest{Rule: rule, Targets: targets}
+		d.held[key] = h
+	}
+	if len(h.Messages) >= d.max {
+		h.Dropped++
+	} else {
+		h.Messages = append(h.Messages, mbus)
+	}
+	d.dirty = true
+}
+
+func (d *digestHolder) watch() {
+	ticker := time.NewTicker(digestCheckInterval)
+	for range ticker.C {
+		d.flush(time.Now())
+	}
+}
+
+// flush delivers digest of which time condition has ended
+func (d *digestHolder) flush(now time.Time) {
+	flushed := make([]*heldDigest, 0)
+
+	d.mutex.Lock()
+	for k, h := range d.held {
+		if d.holding(h.Rule, now) {
+			continue
+		}
+		flushed = append(flushed, h)
+		delete(d.held, k)
+	}
+	if len(flushed) > 0 || d.dirty {
+		d.store()
+	}
+	d.mutex.Unlock()
+
+	for _, h := range flushed {
+		log.Info("deliver digest of rule [%s] : %d messages", h.Rule, h.count())
+		if d.deliver != nil && h.count() > 0 {
+			d.deliver(h.Targets, buildDigest(h))
+		}
+	}
+}
+
+func (d *digestHolder) restore() {
+	data, err := os.ReadFile(d.storePath)
+	if err != nil {
+		return
+	}
+
+	var held map[string]*heldDigest
+	err = json.Unmarshal(data, &held)
+	if err != nil {
+		log.Warn("fail to unmarshal %s : %s", fileStoreDigest, err.Error())
+		return
+	}
+	if held != nil {
+		d.held = held
+	}
+	log.Info("digest restored : %d", len(d.held))
+}
+
+func (d *digestHolder) store() {
+	d.dirty = false
+	if len(d.storePath) == 0 {
+		return
+	}
+
+	b, err := json.Marshal(d.held)
+	if err != nil {
+		log.Warn("fail to build json : %s", err.Error())
+		return
+	}
+
+	err = os.WriteFile(d.storePath, b, 0644)
+	if err != nil {
+		log.Warn("fail to store %s : %s", fileStoreDigest, err.Error())
+	}
+}
+
+func buildDigest(h *heldDigest) domain.MBusMessageBody {
+	first := h.Messages[0]
+	digest := first.Clone()
+	digest.EventTime = int(time.Now().UnixMilli())
+	delete(digest.Message, domain.MessageKeyDeployment)
+	delete(digest.Message, domain.MessageKeyAction)
+
+	var buff bytes.Buffer
+	buff.WriteString(fmt.Sprintf("%d messages held by [%s]", h.count(), h.Rule))
+	level := ""
+	for i, m := range h.Messages {
+		digest.PackageProfile = commonValue(digest.PackageProfile, m.PackageProfile)
+		digest.PackageGroup = commonValue(digest.PackageGroup, m.PackageGroup)
+		digest.PackageHost = commonValue(digest.PackageHost, m.PackageHost)
+		digest.PackageName = commonValue(digest.PackageName, m.PackageName)
+		digest.PackageProcess = commonValue(digest.PackageProcess, m.PackageProcess)
+		level = higherAlarmLevel(level, m.GetAlarmLevel())
+
+		if i >= maxDigestLines {
+			continue
+		}
+		text := strings.TrimSpace(m.GetText())
+		if idx := strings.IndexByte(text, '\n'); idx > 0 {
+			text = text[:idx]
+		}
+		buff.WriteString(fmt.Sprintf("\n%s [%s] %s : %s", formatMillis(m.EventTime), m.GetAlarmLevel(), m.GetSourceKey(), text))
+	}
+	if h.count() > maxDigestLines {
+		buff.WriteString(fmt.Sprintf("\n... and %d more", h.count()-min(len(h.Messages), maxDigestLines)))
+	}
+
+	if len(level) > 0 {
+		digest.Message[domain.MessageKeyAlarmLevel] = level
+	}
+	digest.Message[domain.MessageKeyMessage] = buff.String()
+	digest.Message[domain.MessageKeyDigest] = h.count()
+	return digest
+}
+
+func commonValue(current, value string) string {
+	if current == value {
+		return current
+	}
+	return "*"
+}
+
+func higherAlarmLevel(a, b string) string {
+	rank := map[string]int{domain.AlarmLevelWarn: 1, domain.AlarmLevelMinor: 2, domain.AlarmLevelMajor: 3}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
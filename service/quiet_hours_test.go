@@ -0,0 +1,128 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 22. 오전 10:30
+ */
+
+package service
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fatima-go/saturn/domain"
+)
+
+func TestTimeCondition(t *testing.T) {
+	business := TimeCondition{Days: "weekday", Hours: "09:00-18:00", Timezone: "Asia/Seoul", Holidays: holidayExclude, Outside: true}
+	if err := business.compile(); err != nil {
+		t.Fatalf("compile : %s", err.Error())
+	}
+
+	calendar := &holidayCalendar{file: &dataFile{}, days: map[string]bool{"2026-10-09": true}}
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+
+	cases := []struct {
+		at      time.Time
+		outside bool
+	}{
+		{time.Date(2026, 10, 19, 10, 0, 0, 0, seoul), false}, // monday morning
+		{time.Date(2026, 10, 19, 18, 0, 0, 0, seoul), true},  // monday evening
+		{time.Date(2026, 10, 18, 10, 0, 0, 0, seoul), true},  // sunday
+		{time.Date(2026, 10, 9, 10, 0, 0, 0, seoul), true},   // holiday (friday)
+	}
+	for _, c := range cases {
+		if business.match(c.at, calendar) != c.outside {
+			t.Errorf("%v : expected outside=%v", c.at, c.outside)
+		}
+	}
+
+	night := TimeCondition{Hours: "22:00-07:00"}
+	if err := night.compile(); err != nil {
+		t.Fatalf("compile : %s", err.Error())
+	}
+	if !night.match(time.Date(2026, 10, 19, 23, 30, 0, 0, time.Local), nil) {
+		t.Errorf("23:30 should be in night")
+	}
+	if night.match(time.Date(2026, 10, 19, 7, 0, 0, 0, time.Local), nil) {
+		t.Errorf("07:00 should not be in night")
+	}
+
+	invalid := TimeCondition{Days: "someday"}
+	if invalid.compile() == nil {
+		t.Errorf("invalid days should fail")
+	}
+}
+
+func TestDigestHolder(t *testing.T) {
+	holding := true
+	delivered := make([]domain.MBusMessageBody, 0)
+	isHolding := func(rule string, now time.Time) bool { return holding }
+	holder := newDigestHolder(nil, isHolding, func(targets []string, mbus domain.MBusMessageBody) {
+		delivered = append(delivered, mbus)
+	})
+	holder.storePath = filepath.Join(t.TempDir(), fileStoreDigest)
+	holder.max = 2
+
+	for _, text := range []string{"disk usage over 80%", "disk usage over 85%", "disk usage over 90%"} {
+		holder.hold("night", []string{"slack"}, buildSampleMBusBody(text))
+	}
+	holder.flush(time.Now())
+	if len(delivered) != 0 {
+		t.Fatalf("digest should be held in time window : %d", len(delivered))
+	}
+
+	// held messages survive restart
+	restored := newDigestHolder(nil, isHolding, holder.deliver)
+	restored.storePath = holder.storePath
+	restored.restore()
+	h, ok := restored.held["night|slack"]
+	if !ok || len(h.Messages) != 2 || h.Dropped != 1 {
+		t.Fatalf("expected 2 messages and 1 dropped : %v", restored.held)
+	}
+
+	holding = false
+	restored.flush(time.Now())
+	if len(delivered) != 1 || len(restored.held) != 0 {
+		t.Fatalf("digest should be delivered when time window ends : %d", len(delivered))
+	}
+	if delivered[0].Message[domain.MessageKeyDigest] != 3 {
+		t.Errorf("digest should count dropped messages : %v", delivered[0].Message[domain.MessageKeyDigest])
+	}
+}
+
+func TestDigestHeldDecision(t *testing.T) {
+	notify := &ackNotify{}
+	executor := newTestExecutor([]namedNotify{{name: "slack", notify: notify}})
+	rule := RoutingRule{Name: "night", Notify: []string{"slack"}, Time: &TimeCondition{}, Digest: true}
+	if err := rule.Time.compile(); err != nil {
+		t.Fatalf("compile : %s", err.Error())
+	}
+	executor.router.config = &RoutingConfig{Rules: []RoutingRule{rule}}
+
+	decision, err := executor.decide(buildSampleMBusBody("disk usage over 80%"))
+	if err != nil || decision.Result != domain.DecisionHeld || len(decision.Notified) != 0 {
+		t.Fatalf("expected held decision : %s, %v", decision.Result, err)
+	}
+	if notify.sent != 0 || len(executor.alarm.list(nil)) != 0 {
+		t.Fatalf("held message should not be sent or fire alarm : sent=%d", notify.sent)
+	}
+	if h, ok := executor.router.digest.held["night|slack"]; !ok || h.count() != 1 {
+		t.Fatalf("message should be held by digest rule")
+	}
+}
@@ -21,50 +21,293 @@
 package service
 
 import (
+	"encoding/json"
+	"fmt"
+	"regexp"
 	"sync"
 	"time"
 
+	"github.com/fatima-go/fatima-core"
 	"github.com/fatima-go/fatima-core/lib"
 	log "github.com/fatima-go/fatima-log"
 	"github.com/fatima-go/saturn/domain"
 )
 
 const (
-	diffLimit = 1000 * 3 // 3 sec
+	diffLimit            = 1000 * 3 // 3 sec
+	fileRuleDedup        = "rule.dedup"
+	repeatReportInterval = 1000 * 60 * 10 // report long lasting repeat every 10 min
 )
 
+// DedupConfig is the content of rule.dedup file in data folder
+// first matched rule decides dedup window and fingerprint fields. default is used when no rule matches
+type DedupConfig struct {
+	Default DedupPolicy `json:"default"`
+	Rules   []DedupRule `json:"rules,omitempty"`
+}
+
+type DedupRule struct {
+	Name  string                `json:"name"`
+	Match domain.MessageMatcher `json:"match"`
+	DedupPolicy
+}
+
+// DedupPolicy : window (e.g. 5m), fingerprint fields (e.g. process, category)
+// and mask regex list applied to message text (e.g. [0-9]+)
+// message is redundant when same fingerprint has been seen within window
+type DedupPolicy struct {
+	Window string   `json:"window,omitempty"`
+	Fields []string `json:"fields,omitempty"`
+	Mask   []string `json:"mask,omitempty"`
+
+	window int // millis
+	masks  []*regexp.Regexp
+}
+
+func (p *DedupPolicy) compile() error {
+	p.window = diffLimit
+	if len(p.Window) > 0 {
+		d, err := time.ParseDuration(p.Window)
+		if err != nil {
+			return fmt.Errorf("invalid window %s : %s", p.Window, err.Error())
+		}
+		p.window = int(d.Milliseconds())
+	}
+
+	for _, field := range p.Fields {
+		if _, ok := (domain.MBusMessageBody{}).GetFieldValue(field); !ok {
+			return fmt.Errorf("unknown fingerprint field %s", field)
+		}
+	}
+
+	p.masks = make([]*regexp.Regexp, 0, len(p.Mask))
+	for _, mask := range p.Mask {
+		re, err := regexp.Compile(mask)
+		if err != nil {
+			return fmt.Errorf("invalid mask %s : %s", mask, err.Error())
+		}
+		p.masks = append(p.masks, re)
+	}
+	return nil
+}
+
+func (p *DedupPolicy) fingerprint(mbus domain.MBusMessageBody) string {
+	if len(p.Fields) == 0 {
+		if len(p.masks) == 0 {
+			return mbus.GetHashsum()
+		}
+		return mbus.GetFingerprint(defaultFingerprintFields, p.masks)
+	}
+	return mbus.GetFingerprint(p.Fields, p.masks)
+}
+
+type dedupEntry struct {
+	startTime int // time of notified message
+	lastTime  int
+	window    int
+	count     int // suppressed count since startTime
+	sample    domain.MBusMessageBody
+}
+
+var defaultFingerprintFields = []string{
+	domain.FieldGroup,
+	domain.FieldHost,
+	domain.FieldName,
+	domain.FieldProcess,
+	domain.FieldProfile,
+	domain.FieldMessage,
+}
+
 var mutex sync.Mutex
-var eventMap map[string]int
+var eventMap map[string]dedupEntry
 var mapClearTick *time.Ticker
+var dedupFile *dataFile
+var dedupConfig DedupConfig
+var repeatTick *time.Ticker
+var repeatHandler func(sample, summary domain.MBusMessageBody)
+
+// setRepeatHandler registers handler which receives repeat summary of suppressed messages
+// sample is the first (notified) message of the repeat
+func setRepeatHandler(handler func(sample, summary domain.MBusMessageBody)) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	repeatHandler = handler
+}
+
+// setupDedupRule enables loading rule.dedup from data folder
+func setupDedupRule(fatimaRuntime fatima.FatimaRuntime) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	dedupFile = newDataFile(fatimaRuntime, fileRuleDedup)
+}
 
 func isRedundant(mbus domain.MBusMessageBody) bool {
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	key := mbus.GetHashsum()
-	lastTime, ok := eventMap[key]
-	if !ok {
-		eventMap[key] = lib.CurrentTimeMillis()
-		return false
-	}
+	loadDedupRule()
+	name, policy := findDedupPolicy(mbus)
+	key := name + "." + policy.fingerprint(mbus)
+	now := lib.CurrentTimeMillis()
 
-	if lib.CurrentTimeMillis()-lastTime > diffLimit {
-		eventMap[key] = lib.CurrentTimeMillis()
+	entry, ok := eventMap[key]
+	if !ok || now-entry.lastTime > policy.window {
+		if ok && entry.count > 0 {
+			// window closed but not yet reported by ticker
+			go reportRepeat(entry)
+		}
+		eventMap[key] = dedupEntry{startTime: now, lastTime: now, window: policy.window, sample: mbus}
 		return false
 	}
 
-	eventMap[key] = lib.CurrentTimeMillis()
+	entry.lastTime = now
+	entry.window = policy.window
+	entry.count++
+	eventMap[key] = entry
 	return true
 }
 
+// flushRepeat reports suppressed count of closed window (or long lasting repeat)
+func flushRepeat() {
+	mutex.Lock()
+	now := lib.CurrentTimeMillis()
+	reports := make([]dedupEntry, 0)
+	for k, v := range eventMap {
+		if v.count == 0 {
+			continue
+		}
+
+		if now-v.lastTime > v.window {
+			reports = append(reports, v)
+			delete(eventMap, k)
+			continue
+		}
+
+		if now-v.startTime > repeatReportInterval {
+			reports = append(reports, v)
+			v.startTime = now
+			v.count = 0
+			eventMap[k] = v
+		}
+	}
+	mutex.Unlock()
+
+	for _, entry := range reports {
+		reportRepeat(entry)
+	}
+}
+
+func reportRepeat(entry dedupEntry) {
+	mutex.Lock()
+	handler := repeatHandler
+	mutex.Unlock()
+
+	if handler == nil {
+		return
+	}
+
+	log.Info("message repeated %d times : %s", entry.count, entry.sample.GetSourceKey())
+	handler(entry.sample, buildRepeatSummary(entry))
+}
+
+func buildRepeatSummary(entry dedupEntry) domain.MBusMessageBody {
+	summary := entry.sample.Clone()
+	summary.EventTime = entry.lastTime
+	delete(summary.Message, domain.MessageKeyDeployment)
+
+	duration := time.Duration(entry.lastTime-entry.startTime) * time.Millisecond
+	summary.Message[domain.MessageKeyMessage] = fmt.Sprintf("message repeated %d times in %s (first %s, last %s)\n%s",
+		entry.count,
+		duration.Truncate(time.Second),
+		formatMillis(entry.startTime),
+		formatMillis(entry.lastTime),
+		entry.sample.GetText())
+	summary.Message[domain.MessageKeyRepeatCount] = entry.count
+	return summary
+}
+
+func formatMillis(millis int) string {
+	return time.UnixMilli(int64(millis)).Format("2006-01-02 15:04:05")
+}
+
+func findDedupPolicy(mbus domain.MBusMessageBody) (string, *DedupPolicy) {
+	for i := range dedupConfig.Rules {
+		rule := &dedupConfig.Rules[i]
+		if rule.Match.Match(mbus) {
+			return rule.Name, &rule.DedupPolicy
+		}
+	}
+	return "", &dedupConfig.Default
+}
+
+func loadDedupRule() {
+	if dedupFile == nil {
+		return
+	}
+
+	data, changed := dedupFile.reload()
+	if !changed {
+		return
+	}
+
+	if data == nil {
+		log.Info("%s removed. use default dedup", fileRuleDedup)
+		dedupConfig = defaultDedupConfig()
+		return
+	}
+
+	var config DedupConfig
+	err := json.Unmarshal(data, &config)
+	if err != nil {
+		log.Warn("fail to unmarshal %s : %s", fileRuleDedup, err.Error())
+		return
+	}
+
+	if err = config.Default.compile(); err != nil {
+		log.Warn("invalid default dedup policy : %s", err.Error())
+		config.Default = defaultDedupConfig().Default
+	}
+
+	rules := make([]DedupRule, 0, len(config.Rules))
+	for _, rule := range config.Rules {
+		if err = rule.Match.Compile(); err == nil {
+			err = rule.compile()
+		}
+		if err != nil {
+			log.Warn("skip dedup rule [%s] : %s", rule.Name, err.Error())
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	config.Rules = rules
+
+	dedupConfig = config
+	log.Info("dedup rule loaded : default window=%dms, rules[%d]", config.Default.window, len(config.Rules))
+}
+
+func defaultDedupConfig() DedupConfig {
+	config := DedupConfig{}
+	_ = config.Default.compile()
+	return config
+}
+
 func init() {
-	eventMap = make(map[string]int)
+	eventMap = make(map[string]dedupEntry)
+	dedupConfig = defaultDedupConfig()
 	mapClearTick = time.NewTicker(time.Minute * 1)
 	go func() {
 		for range mapClearTick.C {
 			clearEventMap()
 		}
 	}()
+	repeatTick = time.NewTicker(time.Second * 1)
+	go func() {
+		for range repeatTick.C {
+			flushRepeat()
+		}
+	}()
 }
 
 func clearEventMap() {
@@ -73,7 +316,11 @@ func clearEventMap() {
 
 	removeIdList := make([]string, 0)
 	for k, v := range eventMap {
-		if lib.CurrentTimeMillis()-v > diffLimit {
+		if v.count > 0 {
+			// reported by flushRepeat
+			continue
+		}
+		if lib.CurrentTimeMillis()-v.lastTime > v.window {
 			removeIdList = append(removeIdList, k)
 		}
 	}
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"github.com/fatima-go/fatima-core/lib"
 	"github.com/fatima-go/saturn/domain"
+	"strings"
 	"testing"
 	"time"
 )
@@ -65,6 +66,50 @@ func TestRedundancy(t *testing.T) {
 	}
 }
 
+func TestDedupPolicy(t *testing.T) {
+	policy := DedupPolicy{Window: "5m", Mask: []string{"[0-9]+"}}
+	if err := policy.compile(); err != nil {
+		t.Fatalf("fail to compile : %s", err.Error())
+	}
+
+	m1 := buildSampleMBusBody("queue size exceeded : 1024")
+	m2 := buildSampleMBusBody("queue size exceeded : 2048")
+	if policy.fingerprint(m1) != policy.fingerprint(m2) {
+		t.Fatalf("masked number should not change fingerprint")
+	}
+
+	policy = DedupPolicy{Fields: []string{"process", "category"}}
+	if err := policy.compile(); err != nil {
+		t.Fatalf("fail to compile : %s", err.Error())
+	}
+	m3 := buildSampleMBusBody("sample process shutdowned")
+	m3.PackageHost = "another_host"
+	if policy.fingerprint(m1) != policy.fingerprint(m3) {
+		t.Fatalf("fingerprint should use process and category only")
+	}
+
+	policy = DedupPolicy{Fields: []string{"unknown"}}
+	if policy.compile() == nil {
+		t.Fatalf("unknown field should be reported")
+	}
+}
+
+func TestRepeatSummary(t *testing.T) {
+	sample := buildSampleMBusBody("sample process shutdowned")
+	entry := dedupEntry{startTime: sample.EventTime, lastTime: sample.EventTime + 1000*60*5, count: 47, sample: sample}
+
+	summary := buildRepeatSummary(entry)
+	if !summary.IsRepeatSummary() || sample.IsRepeatSummary() {
+		t.Fatalf("only summary should have repeat count")
+	}
+	if !strings.HasPrefix(summary.GetText(), "message repeated 47 times in 5m0s") {
+		t.Fatalf("unexpected summary : %s", summary.GetText())
+	}
+	if !strings.HasSuffix(summary.GetText(), "\n"+sample.GetText()) {
+		t.Fatalf("summary should end with original message : %s", summary.GetText())
+	}
+}
+
 func buildSampleMBusBody(msg string) domain.MBusMessageBody {
 	m := domain.MBusMessageBody{}
 	m.EventTime = lib.CurrentTimeMillis()
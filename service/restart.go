@@ -0,0 +1,137 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 21. 오전 11:00
+ */
+
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fatima-go/fatima-core"
+	log "github.com/fatima-go/fatima-log"
+	"github.com/fatima-go/saturn/domain"
+)
+
+const (
+	propRestartHold    = "restart.hold"
+	defaultRestartHold = 10 // seconds
+)
+
+type pendingShutdown struct {
+	mbus     domain.MBusMessageBody
+	timer    *time.Timer
+	heldTime time.Time
+}
+
+// restartCorrelator holds shutdown alarm for a while. when startup of the same process arrives in time,
+// single restarted message is sent instead of shutdown and startup
+type restartCorrelator struct {
+	mutex   sync.Mutex
+	hold    time.Duration
+	pending map[string]*pendingShutdown
+	notify  func(sample, notice domain.MBusMessageBody)
+}
+
+func newRestartCorrelator(fatimaRuntime fatima.FatimaRuntime, notify func(sample, notice domain.MBusMessageBody)) *restartCorrelator {
+	correlator := restartCorrelator{}
+	correlator.hold = time.Second * time.Duration(getConfigInt(fatimaRuntime, propRestartHold, defaultRestartHold))
+	correlator.pending = make(map[string]*pendingShutdown)
+	correlator.notify = notify
+
+	log.Info("restart correlator : hold=%s", correlator.hold)
+	return &correlator
+}
+
+// correlate returns true when the message is held or merged into restarted message
+func (r *restartCorrelator) correlate(mbus domain.MBusMessageBody) bool {
+	if r.hold <= 0 || !mbus.IsAlarm() {
+		return false
+	}
+
+	source := mbus.GetSourceKey()
+	if mbus.IsProcessShutdown() {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		if prev, ok := r.pending[source]; ok {
+			// shutdown again without startup. release previous one
+			if prev.timer.Stop() {
+				go r.notify(prev.mbus, prev.mbus)
+			}
+		}
+
+		p := &pendingShutdown{mbus: mbus, heldTime: time.Now()}
+		p.timer = time.AfterFunc(r.hold, func() {
+			r.release(source, p)
+		})
+		r.pending[source] = p
+		return true
+	}
+
+	if !mbus.IsProcessStartup() {
+		return false
+	}
+
+	r.mutex.Lock()
+	p, ok := r.pending[source]
+	if ok {
+		delete(r.pending, source)
+	}
+	r.mutex.Unlock()
+
+	if !ok || !p.timer.Stop() {
+		return false
+	}
+
+	downtime := time.Since(p.heldTime)
+	if mbus.EventTime > 0 && p.mbus.EventTime > 0 && mbus.EventTime >= p.mbus.EventTime {
+		downtime = time.Duration(mbus.EventTime-p.mbus.EventTime) * time.Millisecond
+	}
+
+	log.Info("%s restarted (downtime %s)", source, formatDowntime(downtime))
+	r.notify(mbus, buildRestartedNotice(mbus, downtime))
+	return true
+}
+
+// release sends held shutdown alarm when no startup arrived in time.
+// notify fires alarm and pages on-call for it the same as shutdown which was not held
+func (r *restartCorrelator) release(source string, p *pendingShutdown) {
+	r.mutex.Lock()
+	if r.pending[source] == p {
+		delete(r.pending, source)
+	}
+	r.mutex.Unlock()
+
+	r.notify(p.mbus, p.mbus)
+}
+
+// buildRestartedNotice keeps startup action and deployment so that build information is rendered
+func buildRestartedNotice(startup domain.MBusMessageBody, downtime time.Duration) domain.MBusMessageBody {
+	notice := startup.Clone()
+	notice.Message[domain.MessageKeyMessage] = fmt.Sprintf("process %s restarted (downtime %s)",
+		startup.PackageProcess, formatDowntime(downtime))
+	notice.Message[domain.MessageKeyDowntime] = downtime.Milliseconds()
+	return notice
+}
+
+func formatDowntime(d time.Duration) string {
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 29. 오전 11:20
+ */
+
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatima-go/saturn/domain"
+)
+
+func newTestRestartCorrelator(hold time.Duration) (*restartCorrelator, chan domain.MBusMessageBody) {
+	notices := make(chan domain.MBusMessageBody, 10)
+	correlator := newRestartCorrelator(nil, func(sample, notice domain.MBusMessageBody) {
+		notices <- notice
+	})
+	correlator.hold = hold
+	return correlator, notices
+}
+
+func TestRestartMerge(t *testing.T) {
+	correlator, notices := newTestRestartCorrelator(time.Second * 10)
+
+	if correlator.correlate(buildSampleMBusBody("disk usage over 90%")) {
+		t.Fatalf("alarm other than start/stop should pass")
+	}
+
+	shutdown := buildSampleAction(domain.ActionProcessShutdown)
+	shutdown.EventTime = 1000
+	if !correlator.correlate(shutdown) {
+		t.Fatalf("shutdown should be held")
+	}
+
+	startup := buildSampleAction(domain.ActionProcessStartup)
+	startup.EventTime = 3500
+	if !correlator.correlate(startup) {
+		t.Fatalf("startup in time should be merged")
+	}
+
+	select {
+	case notice := <-notices:
+		if !notice.IsProcessStartup() || !strings.Contains(notice.GetText(), "restarted (downtime 2.5s)") {
+			t.Fatalf("unexpected restarted notice : %s", notice.GetText())
+		}
+		if notice.Message[domain.MessageKeyDowntime] != int64(2500) {
+			t.Fatalf("unexpected downtime : %v", notice.Message[domain.MessageKeyDowntime])
+		}
+	default:
+		t.Fatalf("expected restarted notice")
+	}
+
+	if len(correlator.pending) != 0 {
+		t.Fatalf("pending shutdown should be removed : %d", len(correlator.pending))
+	}
+	if correlator.correlate(buildSampleAction(domain.ActionProcessStartup)) {
+		t.Fatalf("startup without held shutdown should pass")
+	}
+}
+
+func TestRestartHoldTimeout(t *testing.T) {
+	correlator, notices := newTestRestartCorrelator(time.Millisecond * 50)
+
+	if !correlator.correlate(buildSampleAction(domain.ActionProcessShutdown)) {
+		t.Fatalf("shutdown should be held")
+	}
+
+	select {
+	case notice := <-notices:
+		if !notice.IsProcessShutdown() {
+			t.Fatalf("held shutdown should be released as is : %v", notice.Message)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("held shutdown is not released")
+	}
+
+	if correlator.correlate(buildSampleAction(domain.ActionProcessStartup)) {
+		t.Fatalf("startup after hold timeout should pass")
+	}
+	if len(notices) != 0 {
+		t.Fatalf("unexpected notices : %d", len(notices))
+	}
+}
+
+func TestRestartReleaseAlarm(t *testing.T) {
+	executor := newTestExecutor([]namedNotify{{name: "slack", notify: &ackNotify{}}, {name: "slack:oncall", notify: &ackNotify{}}})
+	executor.restart.hold = time.Millisecond * 50
+	executor.oncall.config = &OncallConfig{
+		Policies: []EscalationPolicy{
+			{Name: "ops", Match: domain.MessageMatcher{AlarmLevel: domain.AlarmLevelMajor}, Levels: []EscalationLevel{{Notify: []string{"slack:oncall"}}}},
+		},
+	}
+
+	shutdown := buildSampleAction(domain.ActionProcessShutdown)
+	shutdown.Message[domain.MessageKeyMessage] = "process test shutdown and stays down"
+	shutdown.Message[domain.MessageKeyAlarmLevel] = domain.AlarmLevelMajor
+	decision, _ := executor.decide(shutdown)
+	if decision.Result != domain.DecisionRestart {
+		t.Fatalf("shutdown should be held : %s", decision.Result)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(executor.oncall.list(true)) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	alarms := executor.alarm.list([]string{domain.AlarmStateFiring})
+	if len(alarms) != 1 || !alarms[0].Message.IsProcessShutdown() {
+		t.Fatalf("released shutdown should open alarm : %v", alarms)
+	}
+	pages := executor.oncall.list(true)
+	if len(pages) != 1 || pages[0].Message.GetAlarmId() != alarms[0].Id {
+		t.Fatalf("released shutdown should be paged with its alarm : %v", pages)
+	}
+}
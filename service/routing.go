@@ -0,0 +1,230 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 19. 오후 6:40
+ */
+
+package service
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/fatima-go/fatima-core"
+	log "github.com/fatima-go/fatima-log"
+	"github.com/fatima-go/saturn/domain"
+)
+
+const (
+	fileRuleRouting = "rule.routing"
+)
+
+// RoutingConfig is the content of rule.routing file in data folder
+// rules are evaluated in order. a matched rule sends message to its notifiers and stops
+// evaluation unless continue is set. when no rule matches, default notifiers are used
+// (every notifier in chain if default is not specified)
+// a rule with time condition is evaluated only in its time window. a rule with digest holds
+// messages while its time condition matches and delivers them as one digest when it ends
+type RoutingConfig struct {
+	Default []string      `json:"default,omitempty"`
+	Rules   []RoutingRule `json:"rules"`
+}
+
+type RoutingRule struct {
+	Name     string                `json:"name"`
+	Match    domain.MessageMatcher `json:"match"`
+	Notify   []string              `json:"notify"`
+	Continue bool                  `json:"continue,omitempty"`
+	Time     *TimeCondition        `json:"time,omitempty"`
+	Digest   bool                  `json:"digest,omitempty"`
+}
+
+type messageRouter struct {
+	mutex     sync.Mutex
+	file      *dataFile
+	config    *RoutingConfig // nil when routing file does not exist
+	notifiers []string
+	calendar  *holidayCalendar
+	digest    *digestHolder
+}
+
+// routing is where a message goes. targets receive the message now and digest rules hold it
+type routing struct {
+	targets []string
+	digests []digestRoute
+}
+
+type digestRoute struct {
+	rule    string
+	targets []string
+}
+
+// held returns true when the message is only held by digest rules
+func (r routing) held() bool {
+	return len(r.targets) == 0 && len(r.digests) > 0
+}
+
+func newMessageRouter(fatimaRuntime fatima.FatimaRuntime, notifiers []string, deliver func(targets []string, mbus domain.MBusMessageBody)) *messageRouter {
+	router := messageRouter{}
+	router.file = newDataFile(fatimaRuntime, fileRuleRouting)
+	router.notifiers = notifiers
+	router.calendar = newHolidayCalendar(fatimaRuntime)
+	router.digest = newDigestHolder(fatimaRuntime, router.holding, deliver)
+	return &router
+}
+
+// route returns where the message should go. rules are matched against sample
+func (r *messageRouter) route(sample domain.MBusMessageBody) routing {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.loading()
+	if r.config == nil {
+		return routing{targets: r.notifiers}
+	}
+
+	now := time.Now()
+	route := routing{targets: make([]string, 0)}
+	matched := false
+	for i := range r.config.Rules {
+		rule := &r.config.Rules[i]
+		if !rule.Match.Match(sample) {
+			continue
+		}
+		if rule.Time != nil && !rule.Time.match(now, r.calendar) {
+			continue
+		}
+
+		matched = true
+		if rule.Digest {
+			route.digests = append(route.digests, digestRoute{rule: rule.Name, targets: rule.Notify})
+		} else {
+			route.targets = appendUnique(route.targets, rule.Notify...)
+		}
+		if !rule.Continue {
+			break
+		}
+	}
+
+	if matched {
+		return route
+	}
+
+	if r.config.Default == nil {
+		return routing{targets: r.notifiers}
+	}
+	return routing{targets: r.config.Default}
+}
+
+// hold keeps mbus in digest rules of the route until their time condition ends
+func (r *messageRouter) hold(route routing, mbus domain.MBusMessageBody) {
+	for _, d := range route.digests {
+		r.digest.hold(d.rule, d.targets, mbus)
+	}
+}
+
+func (r *messageRouter) loading() {
+	data, changed := r.file.reload()
+	if !changed {
+		return
+	}
+
+	if data == nil {
+		log.Info("%s removed. deliver to every notifier", fileRuleRouting)
+		r.config = nil
+		return
+	}
+
+	var config RoutingConfig
+	err := json.Unmarshal(data, &config)
+	if err != nil {
+		log.Warn("fail to unmarshal %s : %s", fileRuleRouting, err.Error())
+		return
+	}
+
+	rules := make([]RoutingRule, 0, len(config.Rules))
+	for _, rule := range config.Rules {
+		if err = rule.Match.Compile(); err != nil {
+			log.Warn("skip routing rule [%s] : %s", rule.Name, err.Error())
+			continue
+		}
+		if rule.Time != nil {
+			if err = rule.Time.compile(); err != nil {
+				log.Warn("skip routing rule [%s] : %s", rule.Name, err.Error())
+				continue
+			}
+		} else if rule.Digest {
+			log.Warn("routing rule [%s] : digest without time condition is ignored", rule.Name)
+			rule.Digest = false
+		}
+		for _, name := range rule.Notify {
+			if !containsString(r.notifiers, name) {
+				log.Warn("routing rule [%s] : unknown notifier %s", rule.Name, name)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	config.Rules = rules
+
+	r.config = &config
+	log.Info("routing rule loaded : rules[%d], default%v", len(config.Rules), config.Default)
+}
+
+// holding returns whether messages of digest rule should still be held.
+// digest of removed rule is delivered
+func (r *messageRouter) holding(name string, now time.Time) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.loading()
+	rule := r.findRule(name)
+	if rule == nil || !rule.Digest {
+		return false
+	}
+	return rule.Time.match(now, r.calendar)
+}
+
+func (r *messageRouter) findRule(name string) *RoutingRule {
+	if r.config == nil {
+		return nil
+	}
+	for i := range r.config.Rules {
+		if r.config.Rules[i].Name == name {
+			return &r.config.Rules[i]
+		}
+	}
+	return nil
+}
+
+func appendUnique(list []string, values ...string) []string {
+	for _, v := range values {
+		if !containsString(list, v) {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 19. 오후 8:25
+ */
+
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fatima-go/saturn/domain"
+)
+
+const sampleRoutingRule = `{"default":["slack"],"rules":[
+{"name":"db major","match":{"group":"db*","alarm_level":"MAJOR"},"notify":["jira","slack:dba"],"continue":true},
+{"name":"db","match":{"group":"db*"},"notify":["slack:dba"]},
+{"name":"db others","match":{"group":"db*"},"notify":["alertmanager"]}]}`
+
+func newTestMessageRouter(path string) *messageRouter {
+	router := newMessageRouter(nil, []string{"slack", "slack:dba", "jira", "alertmanager"}, nil)
+	router.file = &dataFile{path: path}
+	return router
+}
+
+func buildRoutingSample(group, level string) domain.MBusMessageBody {
+	mbus := buildSampleMBusBody("disk usage over 90%")
+	mbus.PackageGroup = group
+	mbus.Message[domain.MessageKeyAlarmLevel] = level
+	return mbus
+}
+
+func TestRoutingRule(t *testing.T) {
+	path := filepath.Join(t.TempDir(), fileRuleRouting)
+	if err := os.WriteFile(path, []byte(sampleRoutingRule), 0644); err != nil {
+		t.Fatalf("fail to write rule : %s", err.Error())
+	}
+	router := newTestMessageRouter(path)
+
+	cases := []struct {
+		name    string
+		mbus    domain.MBusMessageBody
+		targets []string
+	}{
+		{"continue", buildRoutingSample("db01", domain.AlarmLevelMajor), []string{"jira", "slack:dba"}},
+		{"first match", buildRoutingSample("db01", domain.AlarmLevelMinor), []string{"slack:dba"}},
+		{"no match", buildRoutingSample("web01", domain.AlarmLevelMajor), []string{"slack"}},
+	}
+	for _, c := range cases {
+		route := router.route(c.mbus)
+		if !reflect.DeepEqual(route.targets, c.targets) || route.held() {
+			t.Errorf("%s : expected %v but %v", c.name, c.targets, route.targets)
+		}
+	}
+}
+
+func TestRoutingWithoutFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), fileRuleRouting)
+	router := newTestMessageRouter(path)
+	every := []string{"slack", "slack:dba", "jira", "alertmanager"}
+
+	if route := router.route(buildRoutingSample("db01", domain.AlarmLevelMajor)); !reflect.DeepEqual(route.targets, every) {
+		t.Fatalf("every notifier without routing file : %v", route.targets)
+	}
+
+	if err := os.WriteFile(path, []byte(sampleRoutingRule), 0644); err != nil {
+		t.Fatalf("fail to write rule : %s", err.Error())
+	}
+	router.file.lastCheckTime = time.Time{}
+	if route := router.route(buildRoutingSample("web01", domain.AlarmLevelMajor)); !reflect.DeepEqual(route.targets, []string{"slack"}) {
+		t.Fatalf("default of routing file : %v", route.targets)
+	}
+
+	// removed file
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("fail to remove rule : %s", err.Error())
+	}
+	router.file.lastCheckTime = time.Time{}
+	if route := router.route(buildRoutingSample("web01", domain.AlarmLevelMajor)); !reflect.DeepEqual(route.targets, every) {
+		t.Fatalf("every notifier after routing file removed : %v", route.targets)
+	}
+}
@@ -0,0 +1,208 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 21. 오후 3:00
+ */
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/fatima-go/fatima-core"
+	"github.com/fatima-go/fatima-core/lib"
+	log "github.com/fatima-go/fatima-log"
+	"github.com/fatima-go/saturn/domain"
+)
+
+const (
+	fileStoreSilence     = "silence.store"
+	silenceRetention     = 1000 * 60 * 60 * 24 * 7 // keep expired silence for 7 days
+	silenceIdLength      = 16
+	defaultSilenceWindow = 1000 * 60 * 60 // 1 hour
+)
+
+// silenceManager keeps silences in data folder. the file can also be edited by hand (hot reloaded)
+type silenceManager struct {
+	mutex    sync.Mutex
+	file     *dataFile
+	silences []domain.Silence
+}
+
+func newSilenceManager(fatimaRuntime fatima.FatimaRuntime) *silenceManager {
+	manager := silenceManager{}
+	manager.file = newDataFile(fatimaRuntime, fileStoreSilence)
+	manager.silences = make([]domain.Silence, 0)
+	manager.mutex.Lock()
+	manager.loading()
+	manager.mutex.Unlock()
+	return &manager
+}
+
+// match returns active silence which matches the message
+func (s *silenceManager) match(mbus domain.MBusMessageBody) (domain.Silence, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.loading()
+	now := lib.CurrentTimeMillis()
+	for i := range s.silences {
+		if s.silences[i].IsActive(now) && s.silences[i].Matcher.Match(mbus) {
+			return s.silences[i], true
+		}
+	}
+	return domain.Silence{}, false
+}
+
+func (s *silenceManager) create(silence domain.Silence) (domain.Silence, error) {
+	now := lib.CurrentTimeMillis()
+	if silence.StartsAt == 0 {
+		silence.StartsAt = now
+	}
+	if silence.EndsAt == 0 {
+		silence.EndsAt = silence.StartsAt + defaultSilenceWindow
+	}
+
+	if silence.EndsAt <= silence.StartsAt {
+		return silence, fmt.Errorf("%w : endsAt should be after startsAt", ErrInvalidParameter)
+	}
+	if silence.EndsAt <= now {
+		return silence, fmt.Errorf("%w : silence already ended", ErrInvalidParameter)
+	}
+	if silence.Matcher.IsEmpty() {
+		return silence, fmt.Errorf("%w : empty matcher silences everything", ErrInvalidParameter)
+	}
+	if err := silence.Matcher.Compile(); err != nil {
+		return silence, fmt.Errorf("%w : %s", ErrInvalidParameter, err.Error())
+	}
+	if len(silence.CreatedBy) == 0 {
+		return silence, fmt.Errorf("%w : createdBy is required", ErrInvalidParameter)
+	}
+
+	silence.Id = lib.RandomAlphanumeric(silenceIdLength)
+	silence.CreatedAt = now
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.loading()
+	s.silences = append(s.silences, silence)
+	s.store()
+	log.Info("silence %s created by %s : %v ~ %v", silence.Id, silence.CreatedBy, formatMillis(silence.StartsAt), formatMillis(silence.EndsAt))
+	return silence, nil
+}
+
+func (s *silenceManager) list(includeExpired bool) []domain.Silence {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.loading()
+	now := lib.CurrentTimeMillis()
+	list := make([]domain.Silence, 0, len(s.silences))
+	for _, v := range s.silences {
+		if !includeExpired && v.IsExpired(now) {
+			continue
+		}
+		list = append(list, v)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].StartsAt < list[j].StartsAt
+	})
+	return list
+}
+
+func (s *silenceManager) expire(id string) (domain.Silence, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.loading()
+	now := lib.CurrentTimeMillis()
+	for i := range s.silences {
+		if s.silences[i].Id != id {
+			continue
+		}
+		if !s.silences[i].IsExpired(now) {
+			s.silences[i].EndsAt = now
+			s.store()
+			log.Info("silence %s expired", id)
+		}
+		return s.silences[i], nil
+	}
+	return domain.Silence{}, fmt.Errorf("%w : silence %s", ErrNotFound, id)
+}
+
+func (s *silenceManager) loading() {
+	data, changed := s.file.reload()
+	if !changed {
+		return
+	}
+
+	if data == nil {
+		s.silences = make([]domain.Silence, 0)
+		return
+	}
+
+	var silences []domain.Silence
+	err := json.Unmarshal(data, &silences)
+	if err != nil {
+		log.Warn("fail to unmarshal %s : %s", fileStoreSilence, err.Error())
+		return
+	}
+
+	loaded := make([]domain.Silence, 0, len(silences))
+	for _, v := range silences {
+		if err = v.Matcher.Compile(); err != nil {
+			log.Warn("skip silence %s : %s", v.Id, err.Error())
+			continue
+		}
+		loaded = append(loaded, v)
+	}
+	s.silences = loaded
+	log.Info("silence loaded : %d", len(s.silences))
+}
+
+func (s *silenceManager) store() {
+	if len(s.file.path) == 0 {
+		return
+	}
+
+	now := lib.CurrentTimeMillis()
+	kept := make([]domain.Silence, 0, len(s.silences))
+	for _, v := range s.silences {
+		if now-v.EndsAt > silenceRetention {
+			continue
+		}
+		kept = append(kept, v)
+	}
+	s.silences = kept
+
+	b, err := json.MarshalIndent(s.silences, "", "  ")
+	if err != nil {
+		log.Warn("fail to build json : %s", err.Error())
+		return
+	}
+
+	err = os.WriteFile(s.file.path, b, 0644)
+	if err != nil {
+		log.Warn("fail to store %s : %s", fileStoreSilence, err.Error())
+	}
+}
@@ -0,0 +1,242 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 20. 오후 2:30
+ */
+
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fatima-go/fatima-core"
+	log "github.com/fatima-go/fatima-log"
+	"github.com/fatima-go/saturn/domain"
+)
+
+const (
+	propStormSourceRate  = "storm.source.rate"
+	propStormSourceBurst = "storm.source.burst"
+	propStormGlobalRate  = "storm.global.rate"
+	propStormGlobalBurst = "storm.global.burst"
+	propStormCalmPeriod  = "storm.calm.period"
+
+	defaultStormSourceRate  = 0 // per minute. disabled unless configured
+	defaultStormSourceBurst = 10
+	defaultStormGlobalRate  = 0 // per minute. disabled unless configured
+	defaultStormGlobalBurst = 30
+	defaultStormCalmPeriod  = 60 // seconds
+	stormGlobalKey          = "*"
+	bucketIdleExpire        = time.Minute * 10
+)
+
+// tokenBucket allows burst messages and refills rate tokens per minute
+type tokenBucket struct {
+	tokens   float64
+	burst    float64
+	rate     float64 // per second
+	lastTime time.Time
+}
+
+func newTokenBucket(ratePerMinute, burst int, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		tokens:   float64(burst),
+		burst:    float64(burst),
+		rate:     float64(ratePerMinute) / 60,
+		lastTime: now,
+	}
+}
+
+func (b *tokenBucket) take(now time.Time) bool {
+	b.tokens += now.Sub(b.lastTime).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.lastTime = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+type storm struct {
+	sample         domain.MBusMessageBody
+	startTime      time.Time
+	lastSuppressed time.Time
+	suppressed     int
+}
+
+// stormGuard limits alarms per group/host/process and globally
+// when limit is crossed, single storm notice is sent and the rest are suppressed until calm period
+type stormGuard struct {
+	mutex       sync.Mutex
+	sourceRate  int
+	sourceBurst int
+	globalRate  int
+	globalBurst int
+	calmPeriod  time.Duration
+	global      *tokenBucket
+	buckets     map[string]*tokenBucket
+	storms      map[string]*storm
+	notify      func(sample, notice domain.MBusMessageBody)
+}
+
+func newStormGuard(fatimaRuntime fatima.FatimaRuntime, notify func(sample, notice domain.MBusMessageBody)) *stormGuard {
+	guard := stormGuard{}
+	guard.sourceRate = getConfigInt(fatimaRuntime, propStormSourceRate, defaultStormSourceRate)
+	guard.sourceBurst = getConfigInt(fatimaRuntime, propStormSourceBurst, defaultStormSourceBurst)
+	guard.globalRate = getConfigInt(fatimaRuntime, propStormGlobalRate, defaultStormGlobalRate)
+	guard.globalBurst = getConfigInt(fatimaRuntime, propStormGlobalBurst, defaultStormGlobalBurst)
+	guard.calmPeriod = time.Second * time.Duration(getConfigInt(fatimaRuntime, propStormCalmPeriod, defaultStormCalmPeriod))
+	guard.global = newTokenBucket(guard.globalRate, guard.globalBurst, time.Now())
+	guard.buckets = make(map[string]*tokenBucket)
+	guard.storms = make(map[string]*storm)
+	guard.notify = notify
+
+	if guard.sourceRate <= 0 && guard.globalRate <= 0 {
+		log.Info("storm guard : disabled")
+		return &guard
+	}
+
+	log.Info("storm guard : source=%d/min(burst %d), global=%d/min(burst %d), calm=%s",
+		guard.sourceRate, guard.sourceBurst, guard.globalRate, guard.globalBurst, guard.calmPeriod)
+
+	go guard.watch()
+	return &guard
+}
+
+// allow returns false when the message should be suppressed by storm protection
+func (g *stormGuard) allow(mbus domain.MBusMessageBody) bool {
+	now := time.Now()
+	g.mutex.Lock()
+
+	if g.sourceRate > 0 {
+		source := mbus.GetSourceKey()
+		bucket, ok := g.buckets[source]
+		if !ok {
+			bucket = newTokenBucket(g.sourceRate, g.sourceBurst, now)
+			g.buckets[source] = bucket
+		}
+		if !bucket.take(now) {
+			begin := g.suppress(source, mbus, now)
+			g.mutex.Unlock()
+			if begin {
+				g.sendNotice(mbus, fmt.Sprintf("storm detected, suppressing alarms from %s", describeStormKey(source)))
+			}
+			return false
+		}
+	}
+
+	if g.globalRate > 0 && !g.global.take(now) {
+		begin := g.suppress(stormGlobalKey, mbus, now)
+		g.mutex.Unlock()
+		if begin {
+			g.sendNotice(mbus, fmt.Sprintf("storm detected, suppressing alarms from %s", describeStormKey(stormGlobalKey)))
+		}
+		return false
+	}
+
+	g.mutex.Unlock()
+	return true
+}
+
+// suppress counts suppressed message and returns true when the storm begins
+func (g *stormGuard) suppress(key string, mbus domain.MBusMessageBody, now time.Time) bool {
+	s, ok := g.storms[key]
+	if ok {
+		s.suppressed++
+		s.lastSuppressed = now
+		return false
+	}
+
+	g.storms[key] = &storm{sample: mbus, startTime: now, lastSuppressed: now, suppressed: 1}
+	log.Warn("storm detected. suppressing alarms from %s", describeStormKey(key))
+	return true
+}
+
+func (g *stormGuard) sendNotice(sample domain.MBusMessageBody, text string) {
+	if g.notify == nil {
+		return
+	}
+	g.notify(sample, buildStormNotice(sample, text))
+}
+
+func (g *stormGuard) watch() {
+	ticker := time.NewTicker(time.Second * 5)
+	for range ticker.C {
+		g.calm(time.Now())
+	}
+}
+
+// calm sends storm summary when things calm down and clears idle buckets
+func (g *stormGuard) calm(now time.Time) {
+	calmed := make(map[string]*storm)
+
+	g.mutex.Lock()
+	for k, s := range g.storms {
+		if now.Sub(s.lastSuppressed) >= g.calmPeriod {
+			calmed[k] = s
+			delete(g.storms, k)
+		}
+	}
+	for k, b := range g.buckets {
+		if now.Sub(b.lastTime) > bucketIdleExpire {
+			delete(g.buckets, k)
+		}
+	}
+	g.mutex.Unlock()
+
+	for k, s := range calmed {
+		text := fmt.Sprintf("storm from %s calmed down : %d alarms suppressed during %s",
+			describeStormKey(k), s.suppressed, s.lastSuppressed.Sub(s.startTime).Truncate(time.Second))
+		log.Info("%s", text)
+		g.sendNotice(s.sample, text)
+	}
+}
+
+func describeStormKey(key string) string {
+	if key == stormGlobalKey {
+		return "all processes"
+	}
+	return key
+}
+
+func buildStormNotice(sample domain.MBusMessageBody, text string) domain.MBusMessageBody {
+	notice := sample.Clone()
+	notice.EventTime = int(time.Now().UnixMilli())
+	delete(notice.Message, domain.MessageKeyDeployment)
+	delete(notice.Message, domain.MessageKeyAction)
+	notice.Message[domain.MessageKeyMessage] = text
+	notice.Message[domain.MessageKeyStorm] = true
+	return notice
+}
+
+func getConfigInt(fatimaRuntime fatima.FatimaRuntime, key string, defaultValue int) int {
+	if fatimaRuntime == nil {
+		return defaultValue
+	}
+
+	v, err := fatimaRuntime.GetConfig().GetInt(key)
+	if err != nil {
+		return defaultValue
+	}
+	return v
+}
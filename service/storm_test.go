@@ -0,0 +1,90 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 29. 오전 10:30
+ */
+
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatima-go/saturn/domain"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	bucket := newTokenBucket(60, 2, now) // 1 token per second
+
+	if !bucket.take(now) || !bucket.take(now) {
+		t.Fatalf("burst should be allowed")
+	}
+	if bucket.take(now) {
+		t.Fatalf("empty bucket should not allow")
+	}
+	if bucket.take(now.Add(time.Millisecond * 500)) {
+		t.Fatalf("half token should not allow")
+	}
+	if !bucket.take(now.Add(time.Second)) {
+		t.Fatalf("refilled token should allow")
+	}
+
+	// refill does not exceed burst
+	later := now.Add(time.Hour)
+	for i := 0; i < 2; i++ {
+		if !bucket.take(later) {
+			t.Fatalf("burst should be allowed after refill")
+		}
+	}
+	if bucket.take(later) {
+		t.Fatalf("tokens should be capped by burst")
+	}
+}
+
+func TestStormCalmSummary(t *testing.T) {
+	notices := make([]domain.MBusMessageBody, 0)
+	guard := newStormGuard(nil, func(sample, notice domain.MBusMessageBody) {
+		notices = append(notices, notice)
+	})
+	if !guard.allow(buildSampleMBusBody("disabled by default")) {
+		t.Fatalf("storm guard should be disabled by default")
+	}
+
+	guard.sourceRate = 1
+	guard.sourceBurst = 1
+	for i := 0; i < 4; i++ {
+		guard.allow(buildSampleMBusBody("disk usage over 90%"))
+	}
+	if len(notices) != 1 || !strings.HasPrefix(notices[0].GetText(), "storm detected") {
+		t.Fatalf("expected storm notice : %v", notices)
+	}
+
+	guard.calm(time.Now())
+	if len(notices) != 1 {
+		t.Fatalf("summary should wait calm period : %v", notices)
+	}
+
+	guard.calm(time.Now().Add(guard.calmPeriod))
+	if len(notices) != 2 || !strings.Contains(notices[1].GetText(), "calmed down : 3 alarms suppressed") {
+		t.Fatalf("expected calm summary : %v", notices)
+	}
+	if notices[1].Message[domain.MessageKeyStorm] != true {
+		t.Fatalf("summary should be marked as storm notice")
+	}
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 27. 오후 2:20
+ */
+
+package service
+
+import (
+	"fmt"
+	"sync"
+
+	log "github.com/fatima-go/fatima-log"
+	"github.com/fatima-go/saturn/domain"
+)
+
+const (
+	subscriberQueueSize = 256
+)
+
+type subscriber struct {
+	matchers []domain.MessageMatcher
+	queue    chan domain.Decision
+	dropped  int
+}
+
+// decisionFeed delivers decisions to live subscribers (e.g. terminal tail, wallboard)
+// slow subscriber loses decisions instead of blocking consume
+type decisionFeed struct {
+	mutex       sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+func newDecisionFeed() *decisionFeed {
+	feed := decisionFeed{}
+	feed.subscribers = make(map[*subscriber]struct{})
+	return &feed
+}
+
+// subscribe registers subscriber of decisions which message matches any of matchers (everything if empty)
+// cancel should be called when the subscriber leaves
+func (d *decisionFeed) subscribe(matchers []domain.MessageMatcher) (<-chan domain.Decision, func(), error) {
+	for i := range matchers {
+		if err := matchers[i].Compile(); err != nil {
+			return nil, nil, fmt.Errorf("%w : %s", ErrInvalidParameter, err.Error())
+		}
+	}
+
+	s := &subscriber{matchers: matchers, queue: make(chan domain.Decision, subscriberQueueSize)}
+	d.mutex.Lock()
+	d.subscribers[s] = struct{}{}
+	count := len(d.subscribers)
+	d.mutex.Unlock()
+	log.Info("subscriber joined. total %d subscribers", count)
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			d.mutex.Lock()
+			delete(d.subscribers, s)
+			count := len(d.subscribers)
+			d.mutex.Unlock()
+			log.Info("subscriber left (%d decisions dropped). total %d subscribers", s.dropped, count)
+		})
+	}
+	return s.queue, cancel, nil
+}
+
+// publish sends decision to subscribers. message of decision should not be modified after publish
+func (d *decisionFeed) publish(decision domain.Decision) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for s := range d.subscribers {
+		if len(s.matchers) > 0 && !matchAny(s.matchers, decision.Message) {
+			continue
+		}
+		select {
+		case s.queue <- decision:
+		default:
+			s.dropped++
+		}
+	}
+}
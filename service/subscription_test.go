@@ -0,0 +1,67 @@
+/*
+ * Copyright 2023 github.com/fatima-go
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * @project fatima-core
+ * @author jin
+ * @date 26. 10. 27. 오후 2:20
+ */
+
+package service
+
+import (
+	"testing"
+
+	"github.com/fatima-go/saturn/domain"
+)
+
+func TestDecisionFeed(t *testing.T) {
+	feed := newDecisionFeed()
+	all, cancelAll, err := feed.subscribe(nil)
+	if err != nil {
+		t.Fatalf("fail to subscribe : %s", err.Error())
+	}
+	db, cancelDb, err := feed.subscribe([]domain.MessageMatcher{{Process: "db*"}})
+	if err != nil {
+		t.Fatalf("fail to subscribe : %s", err.Error())
+	}
+	if _, _, err = feed.subscribe([]domain.MessageMatcher{{Host: "~[invalid"}}); err == nil {
+		t.Fatalf("invalid matcher should be rejected")
+	}
+
+	m := buildSampleMBusBody("disk usage over 90%")
+	feed.publish(domain.Decision{Message: m, Result: domain.DecisionNotified})
+	m.PackageProcess = "dbsync"
+	feed.publish(domain.Decision{Message: m, Result: domain.DecisionRedundant})
+
+	if len(all) != 2 || len(db) != 1 {
+		t.Fatalf("unexpected decisions : all=%d, db=%d", len(all), len(db))
+	}
+	if d := <-db; d.Result != domain.DecisionRedundant {
+		t.Fatalf("unexpected decision : %v", d)
+	}
+
+	cancelDb()
+	cancelDb()
+	for i := 0; i < subscriberQueueSize+10; i++ {
+		feed.publish(domain.Decision{Message: m})
+	}
+	if len(db) != 0 || len(all) != subscriberQueueSize {
+		t.Fatalf("unexpected queue : all=%d, db=%d", len(all), len(db))
+	}
+	cancelAll()
+	if len(feed.subscribers) != 0 {
+		t.Fatalf("subscribers should be removed : %d", len(feed.subscribers))
+	}
+}